@@ -5,8 +5,14 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
 	"io"
 	"net/url"
 	"os"
@@ -17,6 +23,13 @@ import (
 	"sqlpkg.org/cli/httpx"
 )
 
+// hashFuncs maps supported checksum algorithms to hash functions.
+var hashFuncs = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
 // An Asset is an archive of package files for a specific platform.
 type Asset struct {
 	Name     string
@@ -30,26 +43,65 @@ func (a *Asset) Dir() string {
 }
 
 // Validate compares the asset checksum against the provided checksum string.
+// The string consists of one or more whitespace-separated `algo-value` items
+// (e.g. `sha256-<hex>` or `sha384-<base64>` in the SRI style).
+// The asset is valid if it matches any of the items with a supported algorithm.
+// Items that fail to decode are skipped; it's an error only if none decode.
 func (a *Asset) Validate(checksumStr string) (bool, error) {
-	algo, str, ok := strings.Cut(checksumStr, "-")
-	if !ok || algo != "sha256" {
+	supported, decoded := 0, 0
+	for _, item := range strings.Fields(checksumStr) {
+		algo, str, _ := strings.Cut(item, "-")
+		newHash, ok := hashFuncs[algo]
+		if !ok {
+			continue
+		}
+		supported += 1
+
+		checksum, err := decodeChecksum(str, newHash().Size())
+		if err != nil {
+			continue
+		}
+		decoded += 1
+
+		actual, err := a.calcChecksum(algo, newHash)
+		if err != nil {
+			return false, fmt.Errorf("failed to calculate %s checksum: %w", algo, err)
+		}
+
+		if areEqual(actual, checksum) {
+			return true, nil
+		}
+	}
+	if supported == 0 {
 		return false, errors.New("unsupported checksum algorithm")
 	}
-	checksum, err := hex.DecodeString(str)
-	if err != nil {
+	if decoded == 0 {
 		return false, errors.New("failed to decode checksum string")
 	}
-	return areEqual(a.Checksum, checksum), nil
+	return false, nil
+}
+
+// calcChecksum returns the asset checksum using the given algorithm.
+// The SHA-256 checksum is calculated on download, others are calculated on demand.
+func (a *Asset) calcChecksum(algo string, newHash func() hash.Hash) ([]byte, error) {
+	if algo == "sha256" && a.Checksum != nil {
+		return a.Checksum, nil
+	}
+	return fileio.CalcHash(a.Path, newHash())
 }
 
 // Download downloads an asset from the remote url to the local dir.
-func Download(dir, rawURL string) (asset *Asset, err error) {
+func Download(ctx context.Context, client httpx.Client, dir, rawURL string) (asset *Asset, err error) {
 	url, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, errors.New("invalid url")
 	}
+	return DownloadAs(ctx, client, dir, filepath.Base(url.Path), rawURL)
+}
 
-	name := filepath.Base(url.Path)
+// DownloadAs downloads an asset from the remote url
+// to the local dir and saves it under the given name.
+func DownloadAs(ctx context.Context, client httpx.Client, dir, name, rawURL string) (asset *Asset, err error) {
 	path := filepath.Join(dir, name)
 	file, err := os.Create(path)
 	if err != nil {
@@ -57,7 +109,7 @@ func Download(dir, rawURL string) (asset *Asset, err error) {
 	}
 	defer file.Close()
 
-	body, err := httpx.GetBody(rawURL, "application/octet-stream")
+	body, err := httpx.GetBody(ctx, client, rawURL, "application/octet-stream")
 	if err != nil {
 		return nil, err
 	}
@@ -95,15 +147,15 @@ func Copy(dir, path string) (asset *Asset, err error) {
 }
 
 // Unpack unpacks an asset from the given path to the same dir
-// where the asset resides. If pattern is provided, unpacks
-// only the files that match it. Returns the number of unpacked files.
-func Unpack(path, pattern string) (int, error) {
+// where the asset resides. If patterns are provided, unpacks
+// only the files selected by them. Returns the number of unpacked files.
+func Unpack(path string, patterns Patterns) (int, error) {
 	dir, _ := filepath.Split(path)
 	if strings.HasSuffix(path, ".zip") {
-		return unpackZip(path, pattern, dir)
+		return unpackZip(path, patterns, dir)
 	}
 	if strings.HasSuffix(path, ".tar.gz") || strings.HasSuffix(path, ".tgz") {
-		return unpackTarGz(path, pattern, dir)
+		return unpackTarGz(path, patterns, dir)
 	}
 	if strings.HasSuffix(path, ".gz") {
 		return unpackGzip(path, dir)
@@ -112,7 +164,7 @@ func Unpack(path, pattern string) (int, error) {
 }
 
 // unpackZip unpackes a zip archive.
-func unpackZip(path, pattern, dir string) (int, error) {
+func unpackZip(path string, patterns Patterns, dir string) (int, error) {
 	archive, err := zip.OpenReader(path)
 	if err != nil {
 		return 0, err
@@ -125,17 +177,9 @@ func unpackZip(path, pattern, dir string) (int, error) {
 			// ignore dirs
 			continue
 		}
-		if pattern != "" {
-			matched, _ := filepath.Match(pattern, f.Name)
-			if !matched {
-				continue
-			}
-		}
-
-		dstPath := filepath.Join(dir, f.Name)
-		dstFile, err := os.Create(dstPath)
-		if err != nil {
-			return 0, err
+		dst, ok := patterns.Select(f.Name)
+		if !ok {
+			continue
 		}
 
 		file, err := f.Open()
@@ -143,12 +187,11 @@ func unpackZip(path, pattern, dir string) (int, error) {
 			return 0, err
 		}
 
-		_, err = io.Copy(dstFile, file)
+		err = writeFile(dir, dst, file)
+		file.Close()
 		if err != nil {
-			file.Close()
 			return 0, err
 		}
-		dstFile.Close()
 		count += 1
 	}
 
@@ -187,7 +230,7 @@ func unpackGzip(path, dir string) (int, error) {
 }
 
 // unpackTarGz unpackes a .tar.gz archive.
-func unpackTarGz(path, pattern, dir string) (int, error) {
+func unpackTarGz(path string, patterns Patterns, dir string) (int, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return 0, err
@@ -218,28 +261,59 @@ func unpackTarGz(path, pattern, dir string) (int, error) {
 			continue
 		}
 
-		if pattern != "" {
-			matched, _ := filepath.Match(pattern, header.Name)
-			if !matched {
-				continue
-			}
+		dst, ok := patterns.Select(header.Name)
+		if !ok {
+			continue
 		}
 
-		dstPath := filepath.Join(dir, header.Name)
-		dstFile, err := os.Create(dstPath)
+		err = writeFile(dir, dst, rdr)
 		if err != nil {
 			return 0, err
 		}
+		count += 1
+	}
+}
 
-		// copy over contents
-		_, err = io.Copy(dstFile, rdr)
-		if err != nil {
-			return 0, err
-		}
+// writeFile writes an unpacked archive entry to the dir,
+// creating intermediate folders as needed.
+func writeFile(dir, name string, src io.Reader) error {
+	dstPath := filepath.Join(dir, filepath.FromSlash(name))
+	rel, err := filepath.Rel(dir, dstPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("illegal file path: %s", name)
+	}
 
-		dstFile.Close()
-		count += 1
+	err = os.MkdirAll(filepath.Dir(dstPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	dstFile, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	_, err = io.Copy(dstFile, src)
+	return err
+}
+
+// decodeChecksum decodes a hex or base64 checksum value.
+// Chooses the encoding by the length of the digest of the given size,
+// because a base64 value may consist of hex characters only.
+// Values of other lengths are decoded as hex if possible, base64 otherwise.
+func decodeChecksum(str string, size int) ([]byte, error) {
+	switch len(str) {
+	case hex.EncodedLen(size):
+		return hex.DecodeString(str)
+	case base64.StdEncoding.EncodedLen(size):
+		return base64.StdEncoding.DecodeString(str)
+	}
+	checksum, err := hex.DecodeString(str)
+	if err == nil {
+		return checksum, nil
 	}
+	return base64.StdEncoding.DecodeString(str)
 }
 
 // areEqual checks if two slices are equal.
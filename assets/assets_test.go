@@ -1,8 +1,13 @@
 package assets
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"sqlpkg.org/cli/fileio"
@@ -55,8 +60,83 @@ func TestAsset_Validate(t *testing.T) {
 			t.Errorf("Validate: unexpected value %v", ok)
 		}
 	})
+	t.Run("sha512", func(t *testing.T) {
+		checkStr := "sha512-9ca42927f07cfcc254d9d2641efd7625324ec7fa56656293b05ac316a05f5d3434bc0c53d05d6b9a96f483150000184ee005543d682a0c475b3c49707f226664"
+		asset := Asset{
+			Name: "example.zip",
+			Path: filepath.Join("testdata", "example.zip"),
+			Size: 246,
+		}
+		ok, err := asset.Validate(checkStr)
+		if err != nil {
+			t.Fatalf("Validate: unexpected error %v", err)
+		}
+		if !ok {
+			t.Errorf("Validate: unexpected value %v", ok)
+		}
+	})
+	t.Run("sri base64", func(t *testing.T) {
+		checkStr := "sha384-sWiswtek+2GjMKXcbTirTKx6BQjS+Bi/yx7v+fnlr/tGwbHKgWHOAHJcthtF0yVO"
+		asset := Asset{
+			Name: "example.zip",
+			Path: filepath.Join("testdata", "example.zip"),
+			Size: 246,
+		}
+		ok, err := asset.Validate(checkStr)
+		if err != nil {
+			t.Fatalf("Validate: unexpected error %v", err)
+		}
+		if !ok {
+			t.Errorf("Validate: unexpected value %v", ok)
+		}
+	})
+	t.Run("multiple", func(t *testing.T) {
+		checkStr := "md5-0123456789 sha256-000000000000 sha256-a3Ma/HYhBjkBWQ0fM8yXflM9rFwtn5rUW7+V0Jldw2k="
+		asset := Asset{
+			Name:     "example.zip",
+			Path:     filepath.Join("testdata", "example.zip"),
+			Size:     246,
+			Checksum: []byte{0x6b, 0x73, 0x1a, 0xfc, 0x76, 0x21, 0x06, 0x39, 0x01, 0x59, 0x0d, 0x1f, 0x33, 0xcc, 0x97, 0x7e, 0x53, 0x3d, 0xac, 0x5c, 0x2d, 0x9f, 0x9a, 0xd4, 0x5b, 0xbf, 0x95, 0xd0, 0x99, 0x5d, 0xc3, 0x69},
+		}
+		ok, err := asset.Validate(checkStr)
+		if err != nil {
+			t.Fatalf("Validate: unexpected error %v", err)
+		}
+		if !ok {
+			t.Errorf("Validate: unexpected value %v", ok)
+		}
+	})
+	t.Run("invalid encoding", func(t *testing.T) {
+		checkStr := "sha256-???"
+		asset := Asset{
+			Name:     "example.zip",
+			Path:     "/opt/assets/example.zip",
+			Size:     246,
+			Checksum: []byte{0x6b, 0x73, 0x1a, 0xfc, 0x76, 0x21},
+		}
+		_, err := asset.Validate(checkStr)
+		if err == nil {
+			t.Errorf("Validate: expected error, got nil")
+		}
+	})
+	t.Run("skip invalid encoding", func(t *testing.T) {
+		checkStr := "sha256-??? sha256-a3Ma/HYhBjkBWQ0fM8yXflM9rFwtn5rUW7+V0Jldw2k="
+		asset := Asset{
+			Name:     "example.zip",
+			Path:     filepath.Join("testdata", "example.zip"),
+			Size:     246,
+			Checksum: []byte{0x6b, 0x73, 0x1a, 0xfc, 0x76, 0x21, 0x06, 0x39, 0x01, 0x59, 0x0d, 0x1f, 0x33, 0xcc, 0x97, 0x7e, 0x53, 0x3d, 0xac, 0x5c, 0x2d, 0x9f, 0x9a, 0xd4, 0x5b, 0xbf, 0x95, 0xd0, 0x99, 0x5d, 0xc3, 0x69},
+		}
+		ok, err := asset.Validate(checkStr)
+		if err != nil {
+			t.Fatalf("Validate: unexpected error %v", err)
+		}
+		if !ok {
+			t.Errorf("Validate: unexpected value %v", ok)
+		}
+	})
 	t.Run("unsupported algo", func(t *testing.T) {
-		checkStr := "sha512-6b731afc7621"
+		checkStr := "md5-6b731afc7621"
 		asset := Asset{
 			Name:     "example.zip",
 			Path:     "/opt/assets/example.zip",
@@ -73,11 +153,45 @@ func TestAsset_Validate(t *testing.T) {
 	})
 }
 
+func Test_decodeChecksum(t *testing.T) {
+	t.Run("hex", func(t *testing.T) {
+		str := strings.Repeat("ab", 32)
+		got, err := decodeChecksum(str, 32)
+		if err != nil {
+			t.Fatalf("decodeChecksum: unexpected error %v", err)
+		}
+		if want, _ := hex.DecodeString(str); !bytes.Equal(got, want) {
+			t.Errorf("decodeChecksum: unexpected value %x", got)
+		}
+	})
+	t.Run("base64 with hex chars", func(t *testing.T) {
+		// a SHA-384 digest in base64 that looks like a SHA-256 digest in hex
+		str := strings.Repeat("0123456789abcdef", 4)
+		got, err := decodeChecksum(str, 48)
+		if err != nil {
+			t.Fatalf("decodeChecksum: unexpected error %v", err)
+		}
+		if want, _ := base64.StdEncoding.DecodeString(str); !bytes.Equal(got, want) {
+			t.Errorf("decodeChecksum: unexpected value %x", got)
+		}
+	})
+	t.Run("base64", func(t *testing.T) {
+		str := "a3Ma/HYhBjkBWQ0fM8yXflM9rFwtn5rUW7+V0Jldw2k="
+		got, err := decodeChecksum(str, 32)
+		if err != nil {
+			t.Fatalf("decodeChecksum: unexpected error %v", err)
+		}
+		if len(got) != 32 || got[0] != 0x6b {
+			t.Errorf("decodeChecksum: unexpected value %x", got)
+		}
+	})
+}
+
 func TestDownload(t *testing.T) {
-	httpx.Mock()
+	client := httpx.Mock()
 	dir := t.TempDir()
 	t.Run("valid", func(t *testing.T) {
-		asset, err := Download(dir, "https://antonz.org/example.zip")
+		asset, err := Download(context.Background(), client, dir, "https://antonz.org/example.zip")
 		if err != nil {
 			t.Fatalf("Download: unexpected error %v", err)
 		}
@@ -96,7 +210,7 @@ func TestDownload(t *testing.T) {
 		}
 	})
 	t.Run("missing", func(t *testing.T) {
-		_, err := Download(dir, "https://antonz.org/missing.zip")
+		_, err := Download(context.Background(), client, dir, "https://antonz.org/missing.zip")
 		if err == nil {
 			t.Fatal("Download: expected error, got nil")
 		}
@@ -134,7 +248,7 @@ func TestUnpack(t *testing.T) {
 			t.Fatalf("Copy: unexpected error %v", err)
 		}
 
-		count, err := Unpack(asset.Path, "")
+		count, err := Unpack(asset.Path, nil)
 		if err != nil {
 			t.Fatalf("Unpack: unexpected error %v", err)
 		}
@@ -156,7 +270,7 @@ func TestUnpack(t *testing.T) {
 			t.Fatalf("Copy: unexpected error %v", err)
 		}
 
-		count, err := Unpack(asset.Path, "*.dylib")
+		count, err := Unpack(asset.Path, Patterns{{Match: "*.dylib"}})
 		if err != nil {
 			t.Fatalf("Unpack: unexpected error %v", err)
 		}
@@ -178,7 +292,7 @@ func TestUnpack(t *testing.T) {
 			t.Fatalf("Copy: unexpected error %v", err)
 		}
 
-		count, err := Unpack(asset.Path, "")
+		count, err := Unpack(asset.Path, nil)
 		if err != nil {
 			t.Fatalf("Unpack: unexpected error %v", err)
 		}
@@ -200,7 +314,7 @@ func TestUnpack(t *testing.T) {
 			t.Fatalf("Copy: unexpected error %v", err)
 		}
 
-		count, err := Unpack(asset.Path, "*.dylib")
+		count, err := Unpack(asset.Path, Patterns{{Match: "*.dylib"}})
 		if err != nil {
 			t.Fatalf("Unpack: unexpected error %v", err)
 		}
@@ -214,6 +328,67 @@ func TestUnpack(t *testing.T) {
 			t.Error("Unpack: unexpected example.txt")
 		}
 	})
+	t.Run("unzip nested", func(t *testing.T) {
+		path := filepath.Join("testdata", "bundle.zip")
+		dir := t.TempDir()
+		asset, err := Copy(dir, path)
+		if err != nil {
+			t.Fatalf("Copy: unexpected error %v", err)
+		}
+
+		patterns := Patterns{
+			{Match: "bundle/dist/**/*.so", Flatten: true},
+			{Match: "bundle/LICENSE", Rename: "docs/"},
+			{Match: "!**/test/**"},
+		}
+		count, err := Unpack(asset.Path, patterns)
+		if err != nil {
+			t.Fatalf("Unpack: unexpected error %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Unpack: unexpected count %v", count)
+		}
+		for _, name := range []string{"crypto.so", "text.so", filepath.Join("docs", "LICENSE")} {
+			if !fileio.Exists(filepath.Join(dir, name)) {
+				t.Errorf("Unpack: missing %s", name)
+			}
+		}
+		if fileio.Exists(filepath.Join(dir, "testing.so")) {
+			t.Error("Unpack: unexpected testing.so")
+		}
+	})
+	t.Run("untar nested", func(t *testing.T) {
+		path := filepath.Join("testdata", "bundle.tar.gz")
+		dir := t.TempDir()
+		asset, err := Copy(dir, path)
+		if err != nil {
+			t.Fatalf("Copy: unexpected error %v", err)
+		}
+
+		count, err := Unpack(asset.Path, Patterns{{Match: "**/*.so"}})
+		if err != nil {
+			t.Fatalf("Unpack: unexpected error %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Unpack: unexpected count %v", count)
+		}
+		if !fileio.Exists(filepath.Join(dir, "bundle", "dist", "test", "testing.so")) {
+			t.Error("Unpack: missing bundle/dist/test/testing.so")
+		}
+	})
+	t.Run("illegal path", func(t *testing.T) {
+		path := filepath.Join("testdata", "example.zip")
+		dir := t.TempDir()
+		asset, err := Copy(dir, path)
+		if err != nil {
+			t.Fatalf("Copy: unexpected error %v", err)
+		}
+
+		_, err = Unpack(asset.Path, Patterns{{Match: "*.dylib", Rename: "../example.dylib"}})
+		if err == nil {
+			t.Fatal("Unpack: expected error, got nil")
+		}
+	})
 	t.Run("gunzip", func(t *testing.T) {
 		path := filepath.Join("testdata", "example.so.gz")
 		dir := t.TempDir()
@@ -222,7 +397,7 @@ func TestUnpack(t *testing.T) {
 			t.Fatalf("Copy: unexpected error %v", err)
 		}
 
-		count, err := Unpack(asset.Path, "")
+		count, err := Unpack(asset.Path, nil)
 		if err != nil {
 			t.Fatalf("Unpack: unexpected error %v", err)
 		}
@@ -0,0 +1,143 @@
+package assets
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+)
+
+// A Pattern selects files from an asset archive.
+//
+// Match is a glob applied to the full archive entry name.
+// In addition to the path.Match syntax, it supports the `**` segment,
+// which matches zero or more folders (e.g. `dist/**/*.so`).
+// A leading `!` turns the pattern into an exclusion (e.g. `!**/test*`).
+//
+// Rename sets the destination path of the matched file.
+// If it ends with a slash, it's a folder, and the file keeps its name.
+// Flatten drops the archive folders, keeping only the file name.
+type Pattern struct {
+	Match   string `json:"match"`
+	Rename  string `json:"rename,omitempty"`
+	Flatten bool   `json:"flatten,omitempty"`
+}
+
+// IsExclude checks if the pattern excludes files instead of selecting them.
+func (p Pattern) IsExclude() bool {
+	return strings.HasPrefix(p.Match, "!")
+}
+
+// Matches checks if the archive entry name matches the pattern.
+func (p Pattern) Matches(name string) bool {
+	glob := strings.TrimPrefix(p.Match, "!")
+	return matchGlob(glob, name)
+}
+
+// target returns the destination path for the matched archive entry.
+func (p Pattern) target(name string) string {
+	if p.Rename != "" {
+		if strings.HasSuffix(p.Rename, "/") {
+			return p.Rename + path.Base(name)
+		}
+		return p.Rename
+	}
+	if p.Flatten {
+		return path.Base(name)
+	}
+	return name
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+// Plain patterns are encoded as strings.
+func (p Pattern) MarshalJSON() ([]byte, error) {
+	if p.Rename == "" && !p.Flatten {
+		return json.Marshal(p.Match)
+	}
+	type pattern Pattern
+	return json.Marshal(pattern(p))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// Accepts either a glob string or an object.
+func (p *Pattern) UnmarshalJSON(data []byte) error {
+	var match string
+	if err := json.Unmarshal(data, &match); err == nil {
+		*p = Pattern{Match: match}
+		return nil
+	}
+	type pattern Pattern
+	var val pattern
+	err := json.Unmarshal(data, &val)
+	if err != nil {
+		return err
+	}
+	if val.Match == "" {
+		return errors.New("pattern is missing the match glob")
+	}
+	*p = Pattern(val)
+	return nil
+}
+
+// Patterns is an ordered list of file patterns.
+type Patterns []Pattern
+
+// Select checks if the archive entry should be unpacked,
+// and if so, returns its destination path relative to the unpack dir.
+// An entry is selected if it matches any of the inclusion patterns
+// (or there are none) and does not match any of the exclusion patterns.
+// The first matching inclusion pattern determines the destination.
+func (ps Patterns) Select(name string) (string, bool) {
+	var include *Pattern
+	hasIncludes := false
+	for i, p := range ps {
+		if p.IsExclude() {
+			if p.Matches(name) {
+				return "", false
+			}
+			continue
+		}
+		hasIncludes = true
+		if include == nil && p.Matches(name) {
+			include = &ps[i]
+		}
+	}
+	if include != nil {
+		return include.target(name), true
+	}
+	if hasIncludes {
+		return "", false
+	}
+	return name, true
+}
+
+// matchGlob reports whether the name matches the glob pattern.
+// Both are slash-separated paths. The `**` segment matches
+// zero or more path segments, other segments follow path.Match rules.
+func matchGlob(glob, name string) bool {
+	return matchSegments(strings.Split(glob, "/"), strings.Split(name, "/"))
+}
+
+// matchSegments matches path segments against glob segments.
+func matchSegments(globs, names []string) bool {
+	for len(globs) > 0 {
+		if globs[0] == "**" {
+			rest := globs[1:]
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(rest, names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		ok, err := path.Match(globs[0], names[0])
+		if err != nil || !ok {
+			return false
+		}
+		globs, names = globs[1:], names[1:]
+	}
+	return len(names) == 0
+}
@@ -0,0 +1,139 @@
+package assets
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPattern_Matches(t *testing.T) {
+	tests := []struct {
+		match string
+		name  string
+		want  bool
+	}{
+		{"*.so", "text.so", true},
+		{"*.so", "dist/text.so", false},
+		{"dist/*.so", "dist/text.so", true},
+		{"**/*.so", "text.so", true},
+		{"**/*.so", "dist/linux/text.so", true},
+		{"dist/**", "dist/linux/text.so", true},
+		{"dist/**/text.so", "dist/text.so", true},
+		{"dist/**/text.so", "src/text.so", false},
+		{"LICENSE", "LICENSE", true},
+		{"!**/test*", "dist/test/testing.so", true},
+		{"[", "text.so", false},
+	}
+	for _, test := range tests {
+		p := Pattern{Match: test.match}
+		got := p.Matches(test.name)
+		if got != test.want {
+			t.Errorf("Matches(%q, %q): expected %v, got %v", test.match, test.name, test.want, got)
+		}
+	}
+}
+
+func TestPatterns_Select(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		var ps Patterns
+		dst, ok := ps.Select("dist/text.so")
+		if !ok || dst != "dist/text.so" {
+			t.Errorf("Select: unexpected result %q, %v", dst, ok)
+		}
+	})
+	t.Run("include", func(t *testing.T) {
+		ps := Patterns{{Match: "**/*.so"}, {Match: "LICENSE"}}
+		tests := map[string]bool{
+			"dist/text.so": true,
+			"LICENSE":      true,
+			"README.md":    false,
+		}
+		for name, want := range tests {
+			_, ok := ps.Select(name)
+			if ok != want {
+				t.Errorf("Select(%q): expected %v, got %v", name, want, ok)
+			}
+		}
+	})
+	t.Run("exclude only", func(t *testing.T) {
+		ps := Patterns{{Match: "!*.md"}}
+		if _, ok := ps.Select("README.md"); ok {
+			t.Error("Select: expected README.md to be excluded")
+		}
+		if _, ok := ps.Select("text.so"); !ok {
+			t.Error("Select: expected text.so to be selected")
+		}
+	})
+	t.Run("exclude wins", func(t *testing.T) {
+		ps := Patterns{{Match: "**/*.so"}, {Match: "!**/test/**"}}
+		if _, ok := ps.Select("dist/test/testing.so"); ok {
+			t.Error("Select: expected testing.so to be excluded")
+		}
+	})
+	t.Run("flatten", func(t *testing.T) {
+		ps := Patterns{{Match: "**/*.so", Flatten: true}}
+		dst, ok := ps.Select("dist/linux/text.so")
+		if !ok || dst != "text.so" {
+			t.Errorf("Select: unexpected result %q, %v", dst, ok)
+		}
+	})
+	t.Run("rename file", func(t *testing.T) {
+		ps := Patterns{{Match: "dist/libtext.so", Rename: "text.so"}}
+		dst, ok := ps.Select("dist/libtext.so")
+		if !ok || dst != "text.so" {
+			t.Errorf("Select: unexpected result %q, %v", dst, ok)
+		}
+	})
+	t.Run("rename dir", func(t *testing.T) {
+		ps := Patterns{{Match: "LICENSE*", Rename: "docs/"}}
+		dst, ok := ps.Select("LICENSE.txt")
+		if !ok || dst != "docs/LICENSE.txt" {
+			t.Errorf("Select: unexpected result %q, %v", dst, ok)
+		}
+	})
+	t.Run("first include wins", func(t *testing.T) {
+		ps := Patterns{{Match: "dist/*.so", Flatten: true}, {Match: "**/*.so"}}
+		dst, _ := ps.Select("dist/text.so")
+		if dst != "text.so" {
+			t.Errorf("Select: unexpected result %q", dst)
+		}
+	})
+}
+
+func TestPattern_JSON(t *testing.T) {
+	t.Run("unmarshal", func(t *testing.T) {
+		data := []byte(`["*.so", "!test*", {"match": "dist/*.dll", "flatten": true}]`)
+		var got Patterns
+		err := json.Unmarshal(data, &got)
+		if err != nil {
+			t.Fatalf("Unmarshal: unexpected error %v", err)
+		}
+		want := Patterns{
+			{Match: "*.so"},
+			{Match: "!test*"},
+			{Match: "dist/*.dll", Flatten: true},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal: unexpected value %v", got)
+		}
+	})
+	t.Run("unmarshal missing match", func(t *testing.T) {
+		data := []byte(`[{"rename": "text.so"}]`)
+		var got Patterns
+		err := json.Unmarshal(data, &got)
+		if err == nil {
+			t.Fatal("Unmarshal: expected error, got nil")
+		}
+	})
+	t.Run("marshal", func(t *testing.T) {
+		ps := Patterns{{Match: "*.so"}, {Match: "LICENSE", Rename: "docs/"}}
+		got, err := json.Marshal(ps)
+		if err != nil {
+			t.Fatalf("Marshal: unexpected error %v", err)
+		}
+		want := `["*.so",{"match":"LICENSE","rename":"docs/"}]`
+		if string(got) != want {
+			t.Errorf("Marshal: unexpected value %s", got)
+		}
+	})
+}
@@ -0,0 +1,316 @@
+// Package binfile inspects extension library files:
+// ELF (Linux), Mach-O (macOS) and PE (Windows).
+package binfile
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Library file formats.
+const (
+	ELF   = "ELF"
+	MachO = "Mach-O"
+	PE    = "PE"
+)
+
+var ErrUnknownFormat = errors.New("unknown binary format")
+var ErrMismatch = errors.New("library does not match the platform")
+
+// archBits maps GOARCH values to their bitness.
+var archBits = map[string]int{
+	"386":      32,
+	"amd64":    64,
+	"arm":      32,
+	"arm64":    64,
+	"loong64":  64,
+	"mips":     32,
+	"mipsle":   32,
+	"mips64":   64,
+	"mips64le": 64,
+	"ppc64":    64,
+	"ppc64le":  64,
+	"riscv64":  64,
+	"s390x":    64,
+}
+
+// libExts are extensions of library files.
+var libExts = []string{".so", ".dylib", ".dll"}
+
+// An Arch is a processor architecture (named as in GOARCH) and its bitness.
+type Arch struct {
+	Name string
+	Bits int
+}
+
+// String returns the architecture description, e.g. "arm64 (64-bit)".
+func (a Arch) String() string {
+	return fmt.Sprintf("%s (%d-bit)", a.Name, a.Bits)
+}
+
+// Info describes the library file format and architectures.
+// Universal Mach-O files contain several architectures,
+// other formats contain exactly one.
+type Info struct {
+	Format string
+	Archs  []Arch
+}
+
+// Check verifies that the library can be loaded on the given platform
+// (GOOS and GOARCH values). Returns an ErrMismatch error if it can't.
+func (info *Info) Check(goos, goarch string) error {
+	want := FormatFor(goos)
+	if info.Format != want {
+		return fmt.Errorf("%w: %s file, want %s", ErrMismatch, info.Format, want)
+	}
+	wantArch := Arch{Name: goarch, Bits: archBits[goarch]}
+	for _, arch := range info.Archs {
+		if arch == wantArch {
+			return nil
+		}
+	}
+	archs := make([]string, len(info.Archs))
+	for i, arch := range info.Archs {
+		archs[i] = arch.String()
+	}
+	return fmt.Errorf("%w: built for %s, want %s", ErrMismatch, strings.Join(archs, ", "), wantArch)
+}
+
+// FormatFor returns the library file format used by the operating system.
+func FormatFor(goos string) string {
+	switch goos {
+	case "darwin", "ios":
+		return MachO
+	case "windows":
+		return PE
+	default:
+		return ELF
+	}
+}
+
+// IsLibrary checks if the file name looks like a library
+// (e.g. text.so, libtext.so.1, text.dylib or text.dll).
+func IsLibrary(name string) bool {
+	name = strings.ToLower(filepath.Base(name))
+	for _, ext := range libExts {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return strings.Contains(name, ".so.")
+}
+
+// Find returns paths to library files in the directory (recursively).
+func Find(dir string) ([]string, error) {
+	paths := []string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && IsLibrary(path) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// Inspect reads the library file format and architectures.
+// Returns ErrUnknownFormat if the file is not an ELF, Mach-O or PE file.
+func Inspect(path string) (*Info, error) {
+	file, magic, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch detect(magic) {
+	case ELF:
+		return inspectELF(file)
+	case MachO:
+		return inspectMachO(file, magic)
+	case PE:
+		return inspectPE(file)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// open opens the file and reads its magic bytes.
+// Returns ErrUnknownFormat if the file is too short.
+func open(path string) (*os.File, []byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	magic := make([]byte, 4)
+	_, err = io.ReadFull(file, magic)
+	if err != nil {
+		file.Close()
+		return nil, nil, ErrUnknownFormat
+	}
+	return file, magic, nil
+}
+
+// detect returns the file format by its magic bytes.
+func detect(magic []byte) string {
+	switch {
+	case bytes.Equal(magic, []byte(elf.ELFMAG)):
+		return ELF
+	case bytes.HasPrefix(magic, []byte("MZ")):
+		return PE
+	}
+	be := uint32(magic[0])<<24 | uint32(magic[1])<<16 | uint32(magic[2])<<8 | uint32(magic[3])
+	le := uint32(magic[3])<<24 | uint32(magic[2])<<16 | uint32(magic[1])<<8 | uint32(magic[0])
+	switch {
+	case be == macho.MagicFat:
+		return MachO
+	case be == macho.Magic32 || be == macho.Magic64:
+		return MachO
+	case le == macho.Magic32 || le == macho.Magic64:
+		return MachO
+	}
+	return ""
+}
+
+// detectFat checks if the magic bytes belong to a universal Mach-O file.
+func detectFat(magic []byte) bool {
+	return bytes.Equal(magic, []byte{0xca, 0xfe, 0xba, 0xbe})
+}
+
+// inspectELF reads the ELF file architecture.
+func inspectELF(r io.ReaderAt) (*Info, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ELF file: %w", err)
+	}
+	bits := 32
+	if f.Class == elf.ELFCLASS64 {
+		bits = 64
+	}
+	arch := Arch{Name: elfArch(f.Machine, bits, f.ByteOrder.String()), Bits: bits}
+	return &Info{Format: ELF, Archs: []Arch{arch}}, nil
+}
+
+// elfArch converts the ELF machine type to the GOARCH name.
+func elfArch(machine elf.Machine, bits int, byteOrder string) string {
+	le := byteOrder == "LittleEndian"
+	switch machine {
+	case elf.EM_386:
+		return "386"
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_LOONGARCH:
+		return "loong64"
+	case elf.EM_RISCV:
+		if bits == 32 {
+			return "riscv"
+		}
+		return "riscv64"
+	case elf.EM_PPC64:
+		if le {
+			return "ppc64le"
+		}
+		return "ppc64"
+	case elf.EM_S390:
+		return "s390x"
+	case elf.EM_MIPS:
+		name := "mips"
+		if bits == 64 {
+			name = "mips64"
+		}
+		if le {
+			name += "le"
+		}
+		return name
+	}
+	return strings.ToLower(strings.TrimPrefix(machine.String(), "EM_"))
+}
+
+// inspectMachO reads the Mach-O file architectures.
+// Supports both thin and universal (fat) files.
+func inspectMachO(r io.ReaderAt, magic []byte) (*Info, error) {
+	if detectFat(magic) {
+		fat, err := macho.NewFatFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+		}
+		info := &Info{Format: MachO}
+		for _, arch := range fat.Arches {
+			info.Archs = append(info.Archs, machoArch(arch.Cpu))
+		}
+		return info, nil
+	}
+
+	f, err := macho.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+	}
+	return &Info{Format: MachO, Archs: []Arch{machoArch(f.Cpu)}}, nil
+}
+
+// machoArch converts the Mach-O cpu type to the GOARCH name and bitness.
+func machoArch(cpu macho.Cpu) Arch {
+	bits := 32
+	if cpu&0x01000000 != 0 {
+		// CPU_ARCH_ABI64
+		bits = 64
+	}
+	switch cpu {
+	case macho.Cpu386:
+		return Arch{"386", bits}
+	case macho.CpuAmd64:
+		return Arch{"amd64", bits}
+	case macho.CpuArm:
+		return Arch{"arm", bits}
+	case macho.CpuArm64:
+		return Arch{"arm64", bits}
+	case macho.CpuPpc:
+		return Arch{"ppc", bits}
+	case macho.CpuPpc64:
+		return Arch{"ppc64", bits}
+	}
+	return Arch{fmt.Sprintf("cpu%d", uint32(cpu)), bits}
+}
+
+// inspectPE reads the PE file architecture.
+func inspectPE(r io.ReaderAt) (*Info, error) {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PE file: %w", err)
+	}
+	bits := 32
+	if _, ok := f.OptionalHeader.(*pe.OptionalHeader64); ok {
+		bits = 64
+	}
+	var name string
+	switch f.Machine {
+	case pe.IMAGE_FILE_MACHINE_I386:
+		name = "386"
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		name = "amd64"
+	case pe.IMAGE_FILE_MACHINE_ARMNT, pe.IMAGE_FILE_MACHINE_ARM:
+		name = "arm"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		name = "arm64"
+	default:
+		name = fmt.Sprintf("machine%#x", f.Machine)
+	}
+	return &Info{Format: PE, Archs: []Arch{{name, bits}}}, nil
+}
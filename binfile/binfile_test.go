@@ -0,0 +1,107 @@
+package binfile
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInspect(t *testing.T) {
+	tests := []struct {
+		name string
+		want *Info
+	}{
+		{"example.so", &Info{Format: ELF, Archs: []Arch{{"amd64", 64}}}},
+		{"example.dylib", &Info{Format: MachO, Archs: []Arch{{"arm64", 64}}}},
+		{"universal.dylib", &Info{Format: MachO, Archs: []Arch{{"amd64", 64}, {"arm64", 64}}}},
+		{"example.dll", &Info{Format: PE, Archs: []Arch{{"amd64", 64}}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Inspect(filepath.Join("testdata", test.name))
+			if err != nil {
+				t.Fatalf("Inspect: unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Inspect: unexpected value %+v", got)
+			}
+		})
+	}
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := Inspect(filepath.Join("testdata", "text.so"))
+		if !errors.Is(err, ErrUnknownFormat) {
+			t.Fatalf("Inspect: unexpected error %v", err)
+		}
+	})
+}
+
+func TestInfo_Check(t *testing.T) {
+	universal := &Info{Format: MachO, Archs: []Arch{{"amd64", 64}, {"arm64", 64}}}
+	tests := []struct {
+		name          string
+		info          *Info
+		goos, goarch  string
+		wantErrSubstr string
+	}{
+		{"match", &Info{Format: ELF, Archs: []Arch{{"amd64", 64}}}, "linux", "amd64", ""},
+		{"universal", universal, "darwin", "arm64", ""},
+		{"format", &Info{Format: MachO, Archs: []Arch{{"amd64", 64}}}, "linux", "amd64", "Mach-O file, want ELF"},
+		{"arch", &Info{Format: MachO, Archs: []Arch{{"arm64", 64}}}, "darwin", "amd64", "built for arm64 (64-bit), want amd64 (64-bit)"},
+		{"bitness", &Info{Format: PE, Archs: []Arch{{"386", 32}}}, "windows", "amd64", "built for 386 (32-bit), want amd64 (64-bit)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.info.Check(test.goos, test.goarch)
+			if test.wantErrSubstr == "" {
+				if err != nil {
+					t.Fatalf("Check: unexpected error %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrMismatch) {
+				t.Fatalf("Check: unexpected error %v", err)
+			}
+			if !strings.Contains(err.Error(), test.wantErrSubstr) {
+				t.Errorf("Check: unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestIsLibrary(t *testing.T) {
+	tests := map[string]bool{
+		"text.so":         true,
+		"libtext.so.1":    true,
+		"dist/text.dylib": true,
+		"TEXT.DLL":        true,
+		"README.md":       false,
+		"text.sql":        false,
+	}
+	for name, want := range tests {
+		if got := IsLibrary(name); got != want {
+			t.Errorf("IsLibrary(%q): unexpected value %v", name, got)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	paths, err := Find("testdata")
+	if err != nil {
+		t.Fatalf("Find: unexpected error %v", err)
+	}
+	want := []string{
+		filepath.Join("testdata", "example.dll"),
+		filepath.Join("testdata", "example.dylib"),
+		filepath.Join("testdata", "example.so"),
+		filepath.Join("testdata", "lib", "libm.so.6"),
+		filepath.Join("testdata", "oldlib", "libm.so.6"),
+		filepath.Join("testdata", "text.so"),
+		filepath.Join("testdata", "universal.dylib"),
+		filepath.Join("testdata", "win", "kernel32.dll"),
+	}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Find: unexpected value %v", paths)
+	}
+}
@@ -0,0 +1,439 @@
+package binfile
+
+import (
+	"bufio"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Dependencies describes shared libraries required by a library file.
+// Versions maps library names to the symbol versions required
+// from them (e.g. libc.so.6 -> GLIBC_2.29), ELF only.
+// Rpaths are the library's own search paths (ELF RUNPATH/RPATH, Mach-O LC_RPATH).
+type Dependencies struct {
+	Format    string
+	Libraries []string
+	Versions  map[string][]string
+	Rpaths    []string
+}
+
+// Needs reads shared libraries required by the library file.
+// Returns ErrUnknownFormat if the file is not an ELF, Mach-O or PE file.
+func Needs(path string) (*Dependencies, error) {
+	file, magic, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch detect(magic) {
+	case ELF:
+		return elfNeeds(file)
+	case MachO:
+		return machoNeeds(file, magic)
+	case PE:
+		return peNeeds(file)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// elfNeeds reads DT_NEEDED entries and versioned symbol requirements.
+func elfNeeds(r io.ReaderAt) (*Dependencies, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ELF file: %w", err)
+	}
+	deps := &Dependencies{Format: ELF, Versions: map[string][]string{}}
+
+	deps.Libraries, err = f.ImportedLibraries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ELF dependencies: %w", err)
+	}
+
+	needs, err := f.DynamicVersionNeeds()
+	if err != nil && !errors.Is(err, elf.ErrNoSymbols) {
+		return nil, fmt.Errorf("failed to read ELF version requirements: %w", err)
+	}
+	for _, need := range needs {
+		for _, dep := range need.Needs {
+			deps.Versions[need.Name] = append(deps.Versions[need.Name], dep.Dep)
+		}
+	}
+
+	// DT_RPATH is ignored by the loader if DT_RUNPATH is present
+	rpaths, _ := f.DynString(elf.DT_RUNPATH)
+	if len(rpaths) == 0 {
+		rpaths, _ = f.DynString(elf.DT_RPATH)
+	}
+	for _, rpath := range rpaths {
+		deps.Rpaths = append(deps.Rpaths, filepath.SplitList(rpath)...)
+	}
+	return deps, nil
+}
+
+// machoNeeds reads LC_LOAD_DYLIB and LC_RPATH load commands.
+// For universal files, uses the first architecture.
+func machoNeeds(r io.ReaderAt, magic []byte) (*Dependencies, error) {
+	var f *macho.File
+	if detectFat(magic) {
+		fat, err := macho.NewFatFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+		}
+		f = fat.Arches[0].File
+	} else {
+		var err error
+		f, err = macho.NewFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+		}
+	}
+
+	deps := &Dependencies{Format: MachO}
+	var err error
+	deps.Libraries, err = f.ImportedLibraries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Mach-O dependencies: %w", err)
+	}
+	for _, load := range f.Loads {
+		if rpath, ok := load.(*macho.Rpath); ok {
+			deps.Rpaths = append(deps.Rpaths, rpath.Path)
+		}
+	}
+	return deps, nil
+}
+
+// peNeeds reads DLL names from the PE import table.
+func peNeeds(r io.ReaderAt) (*Dependencies, error) {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PE file: %w", err)
+	}
+	// pe.File.ImportedLibraries is not implemented,
+	// so collect library names from imported symbols (symbol:library)
+	symbols, err := f.ImportedSymbols()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PE dependencies: %w", err)
+	}
+	seen := map[string]bool{}
+	deps := &Dependencies{Format: PE, Libraries: []string{}}
+	for _, sym := range symbols {
+		_, lib, ok := strings.Cut(sym, ":")
+		if !ok || seen[strings.ToLower(lib)] {
+			continue
+		}
+		seen[strings.ToLower(lib)] = true
+		deps.Libraries = append(deps.Libraries, lib)
+	}
+	return deps, nil
+}
+
+// A Missing describes a dependency that can't be resolved on the host.
+// If the Version is empty, the library itself is not found.
+// Otherwise, the library is found, but it lacks the required version.
+type Missing struct {
+	Library string
+	Version string
+}
+
+// String describes the missing dependency.
+func (m Missing) String() string {
+	if m.Version == "" {
+		return fmt.Sprintf("library %s is not found", m.Library)
+	}
+	return fmt.Sprintf("library %s does not provide %s", m.Library, m.Version)
+}
+
+// A Host describes where the operating system looks for shared libraries.
+// LibDirs are searched for ELF and PE dependencies, after the library's
+// own search paths. Mach-O dependencies are found by their install names.
+type Host struct {
+	GOOS    string
+	LibDirs []string
+}
+
+// DefaultHost returns library search settings of the current system.
+func DefaultHost() *Host {
+	host := &Host{GOOS: runtime.GOOS}
+	switch FormatFor(host.GOOS) {
+	case ELF:
+		host.LibDirs = elfLibDirs()
+	case PE:
+		host.LibDirs = peLibDirs()
+	}
+	return host
+}
+
+// Unresolved returns dependencies of the library file
+// that can't be found on the host. Skips files that are not
+// binaries or are built for another operating system.
+func (h *Host) Unresolved(path string) ([]Missing, error) {
+	deps, err := Needs(path)
+	if errors.Is(err, ErrUnknownFormat) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if deps.Format != FormatFor(h.GOOS) {
+		return nil, nil
+	}
+
+	origin := filepath.Dir(path)
+	missing := []Missing{}
+	for _, lib := range deps.Libraries {
+		var found string
+		switch deps.Format {
+		case ELF:
+			found = h.findELF(lib, origin, deps.Rpaths)
+		case MachO:
+			found = h.findMachO(lib, origin, deps.Rpaths)
+		case PE:
+			found = h.findPE(lib, origin)
+		}
+		if found == "" {
+			missing = append(missing, Missing{Library: lib})
+			continue
+		}
+		if versions := deps.Versions[lib]; len(versions) != 0 {
+			missing = append(missing, missingVersions(lib, found, versions)...)
+		}
+	}
+	return missing, nil
+}
+
+// findELF returns the path to the ELF dependency, or an empty string
+// if it's not found. Searches the library's rpaths first, then host dirs.
+func (h *Host) findELF(lib, origin string, rpaths []string) string {
+	if strings.Contains(lib, "/") {
+		return existing(lib)
+	}
+	dirs := make([]string, 0, len(rpaths)+len(h.LibDirs))
+	for _, rpath := range rpaths {
+		rpath = strings.ReplaceAll(rpath, "${ORIGIN}", origin)
+		rpath = strings.ReplaceAll(rpath, "$ORIGIN", origin)
+		dirs = append(dirs, rpath)
+	}
+	dirs = append(dirs, h.LibDirs...)
+	for _, dir := range dirs {
+		if path := existing(filepath.Join(dir, lib)); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+// findMachO returns the path to the Mach-O dependency, or an empty string
+// if it's not found. System libraries reside in the dyld shared cache
+// instead of the file system, so they are considered present.
+func (h *Host) findMachO(lib, origin string, rpaths []string) string {
+	if strings.HasPrefix(lib, "/usr/lib/") || strings.HasPrefix(lib, "/System/Library/") {
+		return lib
+	}
+	if rest, ok := strings.CutPrefix(lib, "@loader_path/"); ok {
+		return existing(filepath.Join(origin, rest))
+	}
+	if rest, ok := strings.CutPrefix(lib, "@rpath/"); ok {
+		for _, rpath := range rpaths {
+			rpath = strings.Replace(rpath, "@loader_path", origin, 1)
+			if path := existing(filepath.Join(rpath, rest)); path != "" {
+				return path
+			}
+		}
+		return ""
+	}
+	if strings.HasPrefix(lib, "@") {
+		// @executable_path depends on the host application
+		return lib
+	}
+	return existing(lib)
+}
+
+// findPE returns the path to the DLL, or an empty string if it's not found.
+// Searches the library dir first, then host dirs. API set DLLs
+// (api-ms-win-*, ext-ms-*) are virtual and considered present.
+func (h *Host) findPE(lib, origin string) string {
+	name := strings.ToLower(lib)
+	if strings.HasPrefix(name, "api-ms-win-") || strings.HasPrefix(name, "ext-ms-") {
+		return lib
+	}
+	dirs := append([]string{origin}, h.LibDirs...)
+	for _, dir := range dirs {
+		if path := findFold(dir, lib); path != "" {
+			return path
+		}
+	}
+	return ""
+}
+
+// missingVersions returns the required versions not defined by the ELF library.
+func missingVersions(lib, path string, versions []string) []Missing {
+	f, err := elf.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	defined, err := f.DynamicVersions()
+	if err != nil {
+		defined = nil
+	}
+	has := map[string]bool{}
+	for _, v := range defined {
+		has[v.Name] = true
+	}
+
+	missing := []Missing{}
+	sort.Strings(versions)
+	for _, version := range versions {
+		if !has[version] {
+			missing = append(missing, Missing{Library: lib, Version: version})
+		}
+	}
+	return missing
+}
+
+// existing returns the path if the file exists, or an empty string otherwise.
+func existing(path string) string {
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// findFold returns the path to the file in the dir, matching
+// the name case-insensitively (as Windows does).
+func findFold(dir, name string) string {
+	if path := existing(filepath.Join(dir, name)); path != "" {
+		return path
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), name) {
+			return filepath.Join(dir, entry.Name())
+		}
+	}
+	return ""
+}
+
+// multiarchTriplets maps GOARCH to the Debian multiarch triplets
+// used in library dir names (e.g. /usr/lib/x86_64-linux-gnu).
+var multiarchTriplets = map[string]string{
+	"386":      "i386-linux-gnu",
+	"amd64":    "x86_64-linux-gnu",
+	"arm":      "arm-linux-gnueabihf",
+	"arm64":    "aarch64-linux-gnu",
+	"ppc64le":  "powerpc64le-linux-gnu",
+	"riscv64":  "riscv64-linux-gnu",
+	"s390x":    "s390x-linux-gnu",
+	"loong64":  "loongarch64-linux-gnu",
+	"mips64le": "mips64el-linux-gnuabi64",
+}
+
+// muslArchs maps GOARCH to the musl architecture names
+// used in the musl loader config (/etc/ld-musl-<arch>.path).
+var muslArchs = map[string]string{
+	"386":     "i386",
+	"amd64":   "x86_64",
+	"arm":     "armhf",
+	"arm64":   "aarch64",
+	"ppc64le": "powerpc64le",
+	"riscv64": "riscv64",
+	"s390x":   "s390x",
+}
+
+// elfLibDirs returns directories searched by the dynamic linker:
+// LD_LIBRARY_PATH, /etc/ld.so.conf entries, the musl loader config,
+// and the default dirs (including the multiarch ones).
+func elfLibDirs() []string {
+	dirs := filepath.SplitList(os.Getenv("LD_LIBRARY_PATH"))
+	dirs = append(dirs, readLdConf("/etc/ld.so.conf", 0)...)
+	if arch, ok := muslArchs[runtime.GOARCH]; ok {
+		dirs = append(dirs, readMuslPath("/etc/ld-musl-"+arch+".path")...)
+	}
+	return append(dirs, defaultELFLibDirs(runtime.GOARCH)...)
+}
+
+// defaultELFLibDirs returns the default library dirs for the architecture.
+func defaultELFLibDirs(goarch string) []string {
+	dirs := []string{}
+	if triplet, ok := multiarchTriplets[goarch]; ok {
+		dirs = append(dirs,
+			"/lib/"+triplet, "/usr/lib/"+triplet, "/usr/local/lib/"+triplet,
+		)
+	}
+	return append(dirs,
+		"/lib", "/usr/lib", "/lib64", "/usr/lib64", "/usr/local/lib",
+	)
+}
+
+// readMuslPath reads library dirs from the musl loader config,
+// which lists them separated by newlines or colons.
+func readMuslPath(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return strings.FieldsFunc(string(data), func(r rune) bool {
+		return r == ':' || r == '\n' || r == '\r'
+	})
+}
+
+// readLdConf reads library dirs from the ld.so.conf file,
+// following include directives.
+func readLdConf(path string, depth int) []string {
+	if depth > 8 {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	dirs := []string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if pattern, ok := strings.CutPrefix(line, "include "); ok {
+			pattern = strings.TrimSpace(pattern)
+			if !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(filepath.Dir(path), pattern)
+			}
+			paths, _ := filepath.Glob(pattern)
+			for _, p := range paths {
+				dirs = append(dirs, readLdConf(p, depth+1)...)
+			}
+			continue
+		}
+		dirs = append(dirs, line)
+	}
+	return dirs
+}
+
+// peLibDirs returns directories searched for DLLs:
+// the system directories and PATH.
+func peLibDirs() []string {
+	dirs := []string{}
+	if root := os.Getenv("SystemRoot"); root != "" {
+		dirs = append(dirs, filepath.Join(root, "System32"), root)
+	}
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+	return dirs
+}
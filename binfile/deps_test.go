@@ -0,0 +1,129 @@
+package binfile
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNeeds(t *testing.T) {
+	tests := []struct {
+		name string
+		want *Dependencies
+	}{
+		{"example.so", &Dependencies{
+			Format:    ELF,
+			Libraries: []string{"libm.so.6"},
+			Versions:  map[string][]string{"libm.so.6": {"GLIBC_2.2.5"}},
+		}},
+		{"example.dylib", &Dependencies{
+			Format:    MachO,
+			Libraries: []string{"/usr/lib/libSystem.B.dylib", "@loader_path/libicuuc.74.dylib"},
+		}},
+		{"example.dll", &Dependencies{
+			Format:    PE,
+			Libraries: []string{"KERNEL32.dll", "icuuc74.dll"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Needs(filepath.Join("testdata", test.name))
+			if err != nil {
+				t.Fatalf("Needs: unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Needs: unexpected value %+v", got)
+			}
+		})
+	}
+}
+
+func TestHost_Unresolved(t *testing.T) {
+	tests := []struct {
+		name string
+		host *Host
+		file string
+		want []Missing
+	}{
+		{
+			"elf missing library",
+			&Host{GOOS: "linux"},
+			"example.so",
+			[]Missing{{Library: "libm.so.6"}},
+		},
+		{
+			"elf resolved",
+			&Host{GOOS: "linux", LibDirs: []string{filepath.Join("testdata", "lib")}},
+			"example.so",
+			[]Missing{},
+		},
+		{
+			"elf missing version",
+			&Host{GOOS: "linux", LibDirs: []string{filepath.Join("testdata", "oldlib")}},
+			"example.so",
+			[]Missing{{Library: "libm.so.6", Version: "GLIBC_2.2.5"}},
+		},
+		{
+			"macho",
+			&Host{GOOS: "darwin"},
+			"example.dylib",
+			[]Missing{{Library: "@loader_path/libicuuc.74.dylib"}},
+		},
+		{
+			"pe",
+			&Host{GOOS: "windows", LibDirs: []string{filepath.Join("testdata", "win")}},
+			"example.dll",
+			[]Missing{{Library: "icuuc74.dll"}},
+		},
+		{
+			"other platform",
+			&Host{GOOS: "linux"},
+			"example.dylib",
+			nil,
+		},
+		{
+			"not a binary",
+			&Host{GOOS: "linux"},
+			"text.so",
+			nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.host.Unresolved(filepath.Join("testdata", test.file))
+			if err != nil {
+				t.Fatalf("Unresolved: unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Unresolved: unexpected value %+v", got)
+			}
+		})
+	}
+}
+
+func TestMissing_String(t *testing.T) {
+	m := Missing{Library: "libicuuc.so.74"}
+	if m.String() != "library libicuuc.so.74 is not found" {
+		t.Errorf("String: unexpected value %q", m.String())
+	}
+	m = Missing{Library: "libc.so.6", Version: "GLIBC_2.38"}
+	if m.String() != "library libc.so.6 does not provide GLIBC_2.38" {
+		t.Errorf("String: unexpected value %q", m.String())
+	}
+}
+
+func Test_defaultELFLibDirs(t *testing.T) {
+	t.Run("multiarch", func(t *testing.T) {
+		got := defaultELFLibDirs("arm64")
+		want := []string{"/lib/aarch64-linux-gnu", "/usr/lib/aarch64-linux-gnu", "/usr/local/lib/aarch64-linux-gnu"}
+		if !reflect.DeepEqual(got[:len(want)], want) {
+			t.Errorf("defaultELFLibDirs: unexpected value %v", got)
+		}
+	})
+	t.Run("unknown arch", func(t *testing.T) {
+		got := defaultELFLibDirs("wasm")
+		if got[0] != "/lib" {
+			t.Errorf("defaultELFLibDirs: unexpected value %v", got)
+		}
+	})
+}
@@ -0,0 +1,244 @@
+package binfile
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Mach-O symbol type bits (see <mach-o/nlist.h>).
+const (
+	machoTypeMask = 0x0e // N_TYPE
+	machoSect     = 0x0e // N_SECT
+	machoExt      = 0x01 // N_EXT
+)
+
+// IsEntryPoint checks if the function is an SQLite extension
+// entry point (sqlite3_extension_init or sqlite3_<name>_init).
+func IsEntryPoint(name string) bool {
+	return strings.HasPrefix(name, "sqlite3_") && strings.HasSuffix(name, "_init") &&
+		len(name) > len("sqlite3__init")
+}
+
+// EntryPoints returns SQLite extension entry points
+// exported by the library, sorted by name.
+func EntryPoints(path string) ([]string, error) {
+	symbols, err := Symbols(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := []string{}
+	for _, name := range symbols {
+		if IsEntryPoint(name) {
+			entries = append(entries, name)
+		}
+	}
+	return entries, nil
+}
+
+// Symbols returns names of the functions exported by the library,
+// sorted by name. Returns ErrUnknownFormat if the file
+// is not an ELF, Mach-O or PE file.
+func Symbols(path string) ([]string, error) {
+	file, magic, err := open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var names []string
+	switch detect(magic) {
+	case ELF:
+		names, err = elfSymbols(file)
+	case MachO:
+		names, err = machoSymbols(file, magic)
+	case PE:
+		names, err = peSymbols(file)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// elfSymbols returns functions from the ELF dynamic symbol table
+// that are defined in the library and visible to other objects.
+func elfSymbols(r io.ReaderAt) ([]string, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ELF file: %w", err)
+	}
+	syms, err := f.DynamicSymbols()
+	if errors.Is(err, elf.ErrNoSymbols) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ELF symbols: %w", err)
+	}
+
+	names := []string{}
+	for _, sym := range syms {
+		if sym.Section == elf.SHN_UNDEF || elf.ST_TYPE(sym.Info) != elf.STT_FUNC {
+			continue
+		}
+		bind := elf.ST_BIND(sym.Info)
+		if bind != elf.STB_GLOBAL && bind != elf.STB_WEAK {
+			continue
+		}
+		if elf.ST_VISIBILITY(sym.Other) != elf.STV_DEFAULT {
+			continue
+		}
+		names = append(names, sym.Name)
+	}
+	return names, nil
+}
+
+// machoSymbols returns external symbols defined in the Mach-O file,
+// without the leading underscore. For universal files,
+// combines symbols from all architectures.
+func machoSymbols(r io.ReaderAt, magic []byte) ([]string, error) {
+	var files []*macho.File
+	if detectFat(magic) {
+		fat, err := macho.NewFatFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+		}
+		for _, arch := range fat.Arches {
+			files = append(files, arch.File)
+		}
+	} else {
+		f, err := macho.NewFile(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Mach-O file: %w", err)
+		}
+		files = append(files, f)
+	}
+
+	seen := map[string]bool{}
+	names := []string{}
+	for _, f := range files {
+		if f.Symtab == nil {
+			continue
+		}
+		for _, sym := range f.Symtab.Syms {
+			if sym.Type&machoExt == 0 || sym.Type&machoTypeMask != machoSect {
+				continue
+			}
+			name := strings.TrimPrefix(sym.Name, "_")
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// peSymbols returns function names from the PE export table.
+func peSymbols(r io.ReaderAt) ([]string, error) {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PE file: %w", err)
+	}
+
+	var dirs []pe.DataDirectory
+	switch oh := f.OptionalHeader.(type) {
+	case *pe.OptionalHeader32:
+		dirs = oh.DataDirectory[:min(oh.NumberOfRvaAndSizes, 16)]
+	case *pe.OptionalHeader64:
+		dirs = oh.DataDirectory[:min(oh.NumberOfRvaAndSizes, 16)]
+	}
+	if len(dirs) <= pe.IMAGE_DIRECTORY_ENTRY_EXPORT {
+		return []string{}, nil
+	}
+	exportDir := dirs[pe.IMAGE_DIRECTORY_ENTRY_EXPORT]
+	if exportDir.VirtualAddress == 0 {
+		return []string{}, nil
+	}
+
+	img := peImage{f}
+	// IMAGE_EXPORT_DIRECTORY: NumberOfNames at 0x18, AddressOfNames at 0x20
+	nNames, err := img.uint32(exportDir.VirtualAddress + 0x18)
+	if err != nil {
+		return nil, err
+	}
+	namesAddr, err := img.uint32(exportDir.VirtualAddress + 0x20)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, nNames)
+	for i := uint32(0); i < nNames; i++ {
+		nameAddr, err := img.uint32(namesAddr + i*4)
+		if err != nil {
+			return nil, err
+		}
+		name, err := img.cstring(nameAddr)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+var errInvalidRVA = errors.New("invalid PE file: address is out of bounds")
+
+// peImage reads data from a PE file by relative virtual addresses.
+type peImage struct {
+	f *pe.File
+}
+
+// data returns the section data starting at the given address.
+func (img peImage) data(rva uint32) ([]byte, error) {
+	for _, s := range img.f.Sections {
+		if rva < s.VirtualAddress || rva >= s.VirtualAddress+s.VirtualSize {
+			continue
+		}
+		data, err := s.Data()
+		if err != nil {
+			return nil, err
+		}
+		offset := rva - s.VirtualAddress
+		if offset >= uint32(len(data)) {
+			return nil, errInvalidRVA
+		}
+		return data[offset:], nil
+	}
+	return nil, errInvalidRVA
+}
+
+// uint32 reads a little-endian uint32 at the given address.
+func (img peImage) uint32(rva uint32) (uint32, error) {
+	data, err := img.data(rva)
+	if err != nil {
+		return 0, err
+	}
+	if len(data) < 4 {
+		return 0, errInvalidRVA
+	}
+	return binary.LittleEndian.Uint32(data), nil
+}
+
+// cstring reads a zero-terminated string at the given address.
+func (img peImage) cstring(rva uint32) (string, error) {
+	data, err := img.data(rva)
+	if err != nil {
+		return "", err
+	}
+	end := bytes.IndexByte(data, 0)
+	if end < 0 {
+		return "", errInvalidRVA
+	}
+	return string(data[:end]), nil
+}
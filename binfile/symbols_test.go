@@ -0,0 +1,63 @@
+package binfile
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSymbols(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"example.so", []string{"example_helper", "sqlite3_example_init", "sqlite3_extension_init"}},
+		{"example.dylib", []string{"example_helper", "sqlite3_example_init"}},
+		{"universal.dylib", []string{"example_helper", "sqlite3_example_init"}},
+		{"example.dll", []string{"example_helper", "sqlite3_example_init"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Symbols(filepath.Join("testdata", test.name))
+			if err != nil {
+				t.Fatalf("Symbols: unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Symbols: unexpected value %v", got)
+			}
+		})
+	}
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := Symbols(filepath.Join("testdata", "text.so"))
+		if !errors.Is(err, ErrUnknownFormat) {
+			t.Fatalf("Symbols: unexpected error %v", err)
+		}
+	})
+}
+
+func TestEntryPoints(t *testing.T) {
+	got, err := EntryPoints(filepath.Join("testdata", "example.so"))
+	if err != nil {
+		t.Fatalf("EntryPoints: unexpected error %v", err)
+	}
+	want := []string{"sqlite3_example_init", "sqlite3_extension_init"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EntryPoints: unexpected value %v", got)
+	}
+}
+
+func TestIsEntryPoint(t *testing.T) {
+	tests := map[string]bool{
+		"sqlite3_extension_init": true,
+		"sqlite3_text_init":      true,
+		"sqlite3__init":          false,
+		"sqlite3_text":           false,
+		"text_init":              false,
+	}
+	for name, want := range tests {
+		if got := IsEntryPoint(name); got != want {
+			t.Errorf("IsEntryPoint(%q): unexpected value %v", name, got)
+		}
+	}
+}
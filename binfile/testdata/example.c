@@ -0,0 +1,15 @@
+// Test extension library for the binfile package.
+// Build: gcc -shared -fPIC -O2 -s -o example.so example.c -lm
+#include <math.h>
+
+int sqlite3_example_init(void *db, char **errmsg, const void *api) {
+    return (int)sqrt((double)(long)db);
+}
+
+int sqlite3_extension_init(void *db, char **errmsg, const void *api) {
+    return 0;
+}
+
+int example_helper(void) {
+    return 42;
+}
@@ -2,24 +2,58 @@
 package checksums
 
 import (
+	"context"
+	"encoding/hex"
 	"errors"
 	"os"
+	"path"
+	"regexp"
 	"strings"
 
 	"sqlpkg.org/cli/fileio"
 	"sqlpkg.org/cli/httpx"
 )
 
-// FileName is the checksum filename.
+// FileName is the default checksum filename.
 const FileName = "checksums.txt"
 
+// FileNames are common checksum filenames, in order of preference.
+var FileNames = []string{
+	FileName,
+	"SHA256SUMS",
+	"SHA512SUMS",
+	"sha256sums.txt",
+	"sha512sums.txt",
+}
+
+// SidecarExts are extensions of per-asset checksum files
+// (e.g. sqlean-linux-x86.zip.sha256), in order of preference.
+var SidecarExts = []string{".sha256", ".sha512", ".sha384"}
+
+// hexAlgos maps the length of a hex-encoded checksum to its algorithm.
+var hexAlgos = map[int]string{
+	64:  "sha256",
+	96:  "sha384",
+	128: "sha512",
+}
+
+// bsdAlgos maps BSD-style algorithm tags to algorithms.
+var bsdAlgos = map[string]string{
+	"SHA256": "sha256",
+	"SHA384": "sha384",
+	"SHA512": "sha512",
+}
+
+// e.g. SHA256 (sqlean-linux-x86.zip) = 5072e5737...
+var reBSD = regexp.MustCompile(`^(\w+) ?\((.+)\) ?= ?(\w+)$`)
+
 var ErrInvalidFile = errors.New("invalid checksum file")
 var ErrInvalidSum = errors.New("invalid checksum value")
 
 // Exists checks if a checksum file exists at the given path.
-func Exists(path string, isRemote bool) bool {
+func Exists(ctx context.Context, client httpx.Client, path string, isRemote bool) bool {
 	if isRemote {
-		return httpx.Exists(path)
+		return httpx.Exists(ctx, client, path)
 	} else {
 		return fileio.Exists(path)
 	}
@@ -27,13 +61,45 @@ func Exists(path string, isRemote bool) bool {
 
 // Read loads asset checksums from a local or remote file into a map,
 // where keys are filenames and values are checksums.
-func Read(path string, isRemote bool) (map[string]string, error) {
-	read := inferReader(isRemote)
-	data, err := read(path)
+func Read(ctx context.Context, client httpx.Client, path string, isRemote bool) (map[string]string, error) {
+	data, err := Load(ctx, client, path, isRemote)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// ReadSidecar loads the asset checksum from a local or remote
+// per-asset checksum file (e.g. sqlean-linux-x86.zip.sha256) into a map,
+// where the key is the asset filename and the value is the checksum.
+func ReadSidecar(ctx context.Context, client httpx.Client, path string, isRemote bool) (map[string]string, error) {
+	data, err := Load(ctx, client, path, isRemote)
 	if err != nil {
 		return nil, err
 	}
-	return parse(data)
+	return ParseSidecar(path, data)
+}
+
+// Load reads the raw contents of a local or remote checksum file.
+func Load(ctx context.Context, client httpx.Client, path string, isRemote bool) ([]byte, error) {
+	read := inferReader(ctx, client, isRemote)
+	return read(path)
+}
+
+// Parse parses checksum file contents into a map,
+// where keys are filenames and values are checksums.
+func Parse(data []byte) (map[string]string, error) {
+	return parse(data, "")
+}
+
+// ParseSidecar parses the contents of a per-asset checksum file
+// located at the given path into a map, where the key is the asset filename
+// and the value is the checksum. The sidecar file may contain either
+// a bare checksum or a line in one of the formats supported by Parse.
+func ParseSidecar(filePath string, data []byte) (map[string]string, error) {
+	base := path.Base(filePath)
+	name := strings.TrimSuffix(base, path.Ext(base))
+	return parse(data, name)
 }
 
 // A readFunc if a function that reads a file from a given path.
@@ -41,9 +107,11 @@ type readFunc func(path string) ([]byte, error)
 
 // inferReader returns a proper reader function for a path,
 // which can be a local file path or a remote url path.
-func inferReader(isRemote bool) readFunc {
+func inferReader(ctx context.Context, client httpx.Client, isRemote bool) readFunc {
 	if isRemote {
-		return httpx.GetBytes
+		return func(path string) ([]byte, error) {
+			return httpx.GetBytes(ctx, client, path)
+		}
 	} else {
 		return os.ReadFile
 	}
@@ -52,28 +120,89 @@ func inferReader(isRemote bool) readFunc {
 // parse parses checksum data into a map,
 // where keys are filenames and values are checksums.
 //
-// Expects checksum data in the following format:
+// Expects checksum data in the GNU coreutils format:
 // 5072e5737...(sha-256 checksum)  sqlean-linux-x86.zip
-// f86f443ac...(sha-256 checksum)  sqlean-macos-arm64.zip
-// 8c0dc4fde...(sha-256 checksum)  sqlean-macos-x86.zip
-// 0eead5873...(sha-256 checksum)  sqlean-win-x64.zip
-func parse(data []byte) (map[string]string, error) {
+// f86f443ac...(sha-256 checksum) *sqlean-macos-arm64.zip
+//
+// or in the BSD format:
+// SHA256 (sqlean-macos-x86.zip) = 8c0dc4fde...(sha-256 checksum)
+// SHA256 (sqlean-win-x64.zip) = 0eead5873...(sha-256 checksum)
+//
+// Supports SHA-256, SHA-384 and SHA-512 checksums.
+// If the name is provided, lines with a bare checksum
+// are treated as checksums for the file with that name.
+func parse(data []byte, name string) (map[string]string, error) {
 	lines := strings.Split(string(data), "\n")
 	sums := make(map[string]string, len(lines))
 	for _, line := range lines {
-		if line == "" {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		parts := strings.Fields(line)
-		if len(parts) != 2 {
-			// want `checksum filename` line format
-			return nil, ErrInvalidFile
-		}
-		if len(parts[0]) != 64 {
-			// want sha-256 checksum
-			return nil, ErrInvalidSum
+		filename, checksum, err := parseLine(line, name)
+		if err != nil {
+			return nil, err
 		}
-		sums[parts[1]] = "sha256-" + parts[0]
+		sums[filename] = checksum
 	}
 	return sums, nil
 }
+
+// parseLine parses a single line of the checksum file.
+// Uses the name as a filename if the line contains a bare checksum.
+func parseLine(line, name string) (filename, checksum string, err error) {
+	if match := reBSD.FindStringSubmatch(line); match != nil {
+		algo, ok := bsdAlgos[strings.ToUpper(match[1])]
+		if !ok {
+			return "", "", ErrInvalidSum
+		}
+		checksum, err = formatSum(match[3])
+		if err != nil {
+			return "", "", err
+		}
+		if !strings.HasPrefix(checksum, algo+"-") {
+			// checksum length does not match the algorithm
+			return "", "", ErrInvalidSum
+		}
+		return match[2], checksum, nil
+	}
+
+	sum, rest := line, ""
+	if idx := strings.IndexAny(line, " \t"); idx >= 0 {
+		sum, rest = line[:idx], line[idx+1:]
+	}
+	// the filename is separated from the checksum by a space
+	// and an optional '*' binary mode marker
+	filename = strings.TrimLeft(rest, " \t")
+	filename = strings.TrimPrefix(filename, "*")
+	if filename == "" {
+		if name == "" {
+			// want `checksum filename` line format
+			return "", "", ErrInvalidFile
+		}
+		filename = name
+	}
+
+	checksum, err = formatSum(sum)
+	if err != nil {
+		return "", "", err
+	}
+	return filename, checksum, nil
+}
+
+// formatSum converts a hex checksum to the `algo-hex` string,
+// inferring the algorithm from the checksum length.
+func formatSum(sum string) (string, error) {
+	algo, ok := hexAlgos[len(sum)]
+	if !ok || !isHex(sum) {
+		// want sha-256, sha-384 or sha-512 checksum
+		return "", ErrInvalidSum
+	}
+	return algo + "-" + strings.ToLower(sum), nil
+}
+
+// isHex checks if the string consists of hex digits only.
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -1,6 +1,7 @@
 package checksums
 
 import (
+	"context"
 	"errors"
 	"path/filepath"
 	"testing"
@@ -11,15 +12,15 @@ import (
 func TestExists(t *testing.T) {
 	t.Run("file", func(t *testing.T) {
 		path := filepath.Join("testdata", "checksums.txt")
-		ok := Exists(path, false)
+		ok := Exists(context.Background(), nil, path, false)
 		if !ok {
 			t.Errorf("Exists: unexpected %v", ok)
 		}
 	})
 	t.Run("http", func(t *testing.T) {
-		httpx.Mock()
+		client := httpx.Mock()
 		path := filepath.Join("https://antonz.org/checksums.txt")
-		ok := Exists(path, true)
+		ok := Exists(context.Background(), client, path, true)
 		if !ok {
 			t.Errorf("Exists: unexpected %v", ok)
 		}
@@ -29,7 +30,7 @@ func TestExists(t *testing.T) {
 func TestRead(t *testing.T) {
 	t.Run("file", func(t *testing.T) {
 		path := filepath.Join("testdata", "checksums.txt")
-		sums, err := Read(path, false)
+		sums, err := Read(context.Background(), nil, path, false)
 		if err != nil {
 			t.Fatalf("Read: unexpected error %v", err)
 		}
@@ -47,9 +48,9 @@ func TestRead(t *testing.T) {
 		}
 	})
 	t.Run("http", func(t *testing.T) {
-		httpx.Mock()
+		client := httpx.Mock()
 		path := filepath.Join("https://antonz.org/checksums.txt")
-		sums, err := Read(path, true)
+		sums, err := Read(context.Background(), client, path, true)
 		if err != nil {
 			t.Fatalf("Read: unexpected error %v", err)
 		}
@@ -66,18 +67,107 @@ func TestRead(t *testing.T) {
 			t.Fatalf("Read: unexpected example-win.zip = %v", sums["example-win.zip"])
 		}
 	})
+	t.Run("sha384 and sha512", func(t *testing.T) {
+		path := filepath.Join("testdata", "checksums.sha512")
+		sums, err := Read(context.Background(), nil, path, false)
+		if err != nil {
+			t.Fatalf("Read: unexpected error %v", err)
+		}
+		if len(sums) != 2 {
+			t.Fatalf("Read: unexpected length %v", len(sums))
+		}
+		if sums["example-linux.zip"] != "sha512-9ca42927f07cfcc254d9d2641efd7625324ec7fa56656293b05ac316a05f5d3434bc0c53d05d6b9a96f483150000184ee005543d682a0c475b3c49707f226664" {
+			t.Fatalf("Read: unexpected example-linux.zip = %v", sums["example-linux.zip"])
+		}
+		if sums["example-macos.zip"] != "sha384-b168acc2d7a4fb61a330a5dc6d38ab4cac7a0508d2f818bfcb1eeff9f9e5affb46c1b1ca8161ce00725cb61b45d3254e" {
+			t.Fatalf("Read: unexpected example-macos.zip = %v", sums["example-macos.zip"])
+		}
+	})
+	t.Run("bsd", func(t *testing.T) {
+		path := filepath.Join("testdata", "checksums.bsd")
+		sums, err := Read(context.Background(), nil, path, false)
+		if err != nil {
+			t.Fatalf("Read: unexpected error %v", err)
+		}
+		if len(sums) != 2 {
+			t.Fatalf("Read: unexpected length %v", len(sums))
+		}
+		if sums["example-linux.zip"] != "sha256-6bc24897dde2c7f00cf435055a6853358cb06fcb5a2a789877903ebec0b9298d" {
+			t.Fatalf("Read: unexpected example-linux.zip = %v", sums["example-linux.zip"])
+		}
+		if sums["example-macos.zip"][:19] != "sha512-9ca42927f07c" {
+			t.Fatalf("Read: unexpected example-macos.zip = %v", sums["example-macos.zip"])
+		}
+	})
+	t.Run("binary marker", func(t *testing.T) {
+		path := filepath.Join("testdata", "SHA256SUMS")
+		sums, err := Read(context.Background(), nil, path, false)
+		if err != nil {
+			t.Fatalf("Read: unexpected error %v", err)
+		}
+		if len(sums) != 2 {
+			t.Fatalf("Read: unexpected length %v", len(sums))
+		}
+		if sums["example-linux.zip"] != "sha256-6bc24897dde2c7f00cf435055a6853358cb06fcb5a2a789877903ebec0b9298d" {
+			t.Fatalf("Read: unexpected example-linux.zip = %v", sums["example-linux.zip"])
+		}
+		if sums["example win.zip"] != "sha256-f0d2d705bbe641bf2950a51253820e85de04373b7f428f109f69df1d85fa0654" {
+			t.Fatalf("Read: unexpected example win.zip = %v", sums["example win.zip"])
+		}
+	})
+	t.Run("algorithm mismatch", func(t *testing.T) {
+		path := filepath.Join("testdata", "checksums.mismatch")
+		_, err := Read(context.Background(), nil, path, false)
+		if !errors.Is(err, ErrInvalidSum) {
+			t.Fatalf("Read: expected ErrInvalidSum, got %v", err)
+		}
+	})
 	t.Run("invalid file", func(t *testing.T) {
 		path := filepath.Join("testdata", "checksums.json")
-		_, err := Read(path, false)
+		_, err := Read(context.Background(), nil, path, false)
 		if !errors.Is(err, ErrInvalidFile) {
 			t.Fatalf("Read: expected ErrInvalidFile, got %v", err)
 		}
 	})
 	t.Run("invalid sum", func(t *testing.T) {
 		path := filepath.Join("testdata", "checksums.sha1")
-		_, err := Read(path, false)
+		_, err := Read(context.Background(), nil, path, false)
 		if !errors.Is(err, ErrInvalidSum) {
 			t.Fatalf("Read: expected ErrInvalidSum, got %v", err)
 		}
 	})
 }
+
+func TestReadSidecar(t *testing.T) {
+	t.Run("bare checksum", func(t *testing.T) {
+		path := filepath.Join("testdata", "example-linux.zip.sha256")
+		sums, err := ReadSidecar(context.Background(), nil, path, false)
+		if err != nil {
+			t.Fatalf("ReadSidecar: unexpected error %v", err)
+		}
+		if len(sums) != 1 {
+			t.Fatalf("ReadSidecar: unexpected length %v", len(sums))
+		}
+		if sums["example-linux.zip"] != "sha256-6bc24897dde2c7f00cf435055a6853358cb06fcb5a2a789877903ebec0b9298d" {
+			t.Fatalf("ReadSidecar: unexpected example-linux.zip = %v", sums["example-linux.zip"])
+		}
+	})
+	t.Run("http", func(t *testing.T) {
+		client := httpx.Mock()
+		path := "https://antonz.org/example-linux.zip.sha256"
+		sums, err := ReadSidecar(context.Background(), client, path, true)
+		if err != nil {
+			t.Fatalf("ReadSidecar: unexpected error %v", err)
+		}
+		if sums["example-linux.zip"] != "sha256-6bc24897dde2c7f00cf435055a6853358cb06fcb5a2a789877903ebec0b9298d" {
+			t.Fatalf("ReadSidecar: unexpected example-linux.zip = %v", sums["example-linux.zip"])
+		}
+	})
+	t.Run("missing", func(t *testing.T) {
+		path := filepath.Join("testdata", "missing.zip.sha256")
+		_, err := ReadSidecar(context.Background(), nil, path, false)
+		if err == nil {
+			t.Fatal("ReadSidecar: expected error, got nil")
+		}
+	})
+}
@@ -2,64 +2,39 @@
 package cmd
 
 import (
-	"errors"
 	"os"
-	"strings"
 
 	"sqlpkg.org/cli/fileio"
 	"sqlpkg.org/cli/logx"
 	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
-// WorkDir is the current working directory.
-var WorkDir string
-
-// userHomeDir is the user's home directory.
-var userHomeDir string
-
-func init() {
-	inferWorkDir()
-}
-
-// GetDirByFullName expands an owner-name package pair to a full package dir.
-func GetDirByFullName(fullName string) (string, error) {
-	parts := strings.Split(fullName, "/")
-	if len(parts) != 2 {
-		return "", errors.New("invalid package name")
-	}
-	path := spec.Dir(WorkDir, parts[0], parts[1])
-	return path, nil
-}
-
-// GetPathByFullName expands an owner-name package pair to a full sqlpkg.json path.
-func GetPathByFullName(fullName string) (string, error) {
-	parts := strings.Split(fullName, "/")
-	if len(parts) != 2 {
-		return "", errors.New("invalid package name")
-	}
-	path := spec.Path(WorkDir, parts[0], parts[1])
-	return path, nil
+// NewManager creates a package manager for the inferred scope
+// that reports progress to stdout.
+func NewManager() *sqlpkg.Manager {
+	m := sqlpkg.New(inferDir())
+	m.Logger = logx.NewLogger(os.Stdout)
+	return m
 }
 
 // PrintScope prints information about the current scope (project/global).
-func PrintScope() {
-	if WorkDir == "." {
-		logx.Log("(project scope)")
+func PrintScope(m *sqlpkg.Manager) {
+	if m.Dir == "." {
+		m.Logger.Log("(project scope)")
 	}
 }
 
-// inferWorkDir determines the working directory.
-// It is either the .sqlpkg directory (if present) or ~/.sqlpkg otherwise.
-func inferWorkDir() {
+// inferDir determines the root directory of the current scope.
+// It is either the current directory (if it contains .sqlpkg)
+// or the user's home directory otherwise.
+func inferDir() string {
 	if fileio.Exists(spec.DirName) {
-		WorkDir = "."
-		return
+		return "."
 	}
-	var err error
-	userHomeDir, err = os.UserHomeDir()
+	home, err := os.UserHomeDir()
 	if err != nil {
-		WorkDir = "."
-		return
+		return "."
 	}
-	WorkDir = userHomeDir
+	return home
 }
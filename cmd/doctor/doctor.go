@@ -0,0 +1,138 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"sqlpkg.org/cli/binfile"
+	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const doctorHelp = "usage: sqlpkg doctor [package]"
+
+// A check inspects an installed package and returns the problems found.
+type check func(m *sqlpkg.Manager, pkg *spec.Package) ([]string, error)
+
+// checks are performed for each installed package, in order.
+var checks = []check{
+	checkFiles,
+	checkPlatform,
+	checkDependencies,
+	checkConflicts,
+}
+
+// Doctor checks installed packages for problems.
+// Checks all installed packages if the package is not specified.
+func Doctor(m *sqlpkg.Manager, args []string) error {
+	if len(args) > 1 {
+		return errors.New(doctorHelp)
+	}
+
+	cmd.PrintScope(m)
+
+	packages, err := gatherPackages(m, args)
+	if err != nil {
+		return err
+	}
+	if len(packages) == 0 {
+		m.Logger.Log("no packages installed")
+		return nil
+	}
+
+	failed := 0
+	for _, pkg := range packages {
+		m.Logger.Log("> checking %s...", pkg.FullName())
+		problems := checkPackage(m, pkg)
+		if len(problems) == 0 {
+			m.Logger.Log("✓ no problems found")
+			continue
+		}
+		for _, problem := range problems {
+			m.Logger.Log("! %s", problem)
+		}
+		failed += 1
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("found problems in %d of %d packages", failed, len(packages))
+	}
+	return nil
+}
+
+// gatherPackages returns the specified package
+// or all installed packages if none is specified.
+func gatherPackages(m *sqlpkg.Manager, args []string) ([]*spec.Package, error) {
+	if len(args) == 0 {
+		return m.List()
+	}
+	pkg := m.ReadInstalledSpec(args[0])
+	if pkg == nil {
+		return nil, sqlpkg.ErrNotInstalled
+	}
+	return []*spec.Package{pkg}, nil
+}
+
+// checkPackage runs all checks for the package.
+func checkPackage(m *sqlpkg.Manager, pkg *spec.Package) []string {
+	problems := []string{}
+	for _, check := range checks {
+		found, err := check(m, pkg)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		problems = append(problems, found...)
+	}
+	return problems
+}
+
+// checkFiles checks package files against the package manifest.
+func checkFiles(m *sqlpkg.Manager, pkg *spec.Package) ([]string, error) {
+	report, err := m.VerifyFiles(pkg)
+	if errors.Is(err, sqlpkg.ErrMissingManifest) {
+		m.Logger.Debug("%s, skipping files check", err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if report.OK() {
+		return nil, nil
+	}
+	problem := fmt.Sprintf(
+		"%d modified, %d missing, %d extra files (run `sqlpkg verify %s` for details)",
+		len(report.Modified), len(report.Missing), len(report.Extra), pkg.FullName(),
+	)
+	return []string{problem}, nil
+}
+
+// checkPlatform checks that the package libraries
+// can be loaded on the current platform.
+func checkPlatform(m *sqlpkg.Manager, pkg *spec.Package) ([]string, error) {
+	dir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+	return m.CheckLibraries(dir, m.Platform)
+}
+
+// checkDependencies checks that the shared libraries
+// required by the package are present on the host.
+func checkDependencies(m *sqlpkg.Manager, pkg *spec.Package) ([]string, error) {
+	dir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+	return sqlpkg.FindUnresolved(dir, binfile.DefaultHost())
+}
+
+// checkConflicts checks that no other installed package
+// defines the same symbols as the package.
+func checkConflicts(m *sqlpkg.Manager, pkg *spec.Package) ([]string, error) {
+	conflicts, err := m.FindConflicts(pkg)
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]string, len(conflicts))
+	for i, c := range conflicts {
+		problems[i] = fmt.Sprintf("%s also defines %s", c.Package, strings.Join(c.Symbols, ", "))
+	}
+	return problems, nil
+}
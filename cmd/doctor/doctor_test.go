@@ -0,0 +1,127 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestDoctor(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("healthy", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"nalgeon/example"}
+		err := Doctor(m, args)
+		if err != nil {
+			t.Fatalf("doctor error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "> checking nalgeon/example...")
+		mem.MustHave(t, "✓ no problems found")
+	})
+	t.Run("platform", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"nalgeon/sparc"}
+		err := Doctor(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "found problems in 1 of 1 packages") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "! sparc.so: library does not match the platform")
+	})
+	t.Run("files", func(t *testing.T) {
+		path := filepath.Join(spec.Dir(m.Dir, "nalgeon", "example"), "example.so")
+		err := os.WriteFile(path, []byte("tampered"), 0644)
+		if err != nil {
+			t.Fatalf("WriteFile: unexpected error %v", err)
+		}
+
+		mem := logx.Mock(m.Logger)
+		args := []string{"nalgeon/example"}
+		err = Doctor(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+
+		mem.Print()
+		mem.MustHave(t, "! 1 modified, 0 missing, 0 extra files")
+	})
+	t.Run("not installed", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		args := []string{"sqlite/unknown"}
+		err := Doctor(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "package is not installed") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestDoctorAll(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
+
+	args := []string{}
+	err := Doctor(m, args)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "found problems in 1 of 2 packages") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, "> checking nalgeon/example...")
+	mem.MustHave(t, "> checking nalgeon/sparc...")
+}
+
+func TestDoctorConflicts(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+
+	packages := []*spec.Package{
+		{Owner: "nalgeon", Name: "stats", Symbols: []string{"median", "stddev"}},
+		{Owner: "sqlite", Name: "median", Symbols: []string{"MEDIAN"}},
+	}
+	for _, pkg := range packages {
+		dir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			t.Fatalf("MkdirAll: unexpected error %v", err)
+		}
+		err = pkg.Save(dir)
+		if err != nil {
+			t.Fatalf("Save: unexpected error %v", err)
+		}
+	}
+	mem := logx.Mock(m.Logger)
+
+	err := Doctor(m, nil)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "found problems in 2 of 2 packages") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, "! sqlite/median also defines median")
+	mem.MustHave(t, "! nalgeon/stats also defines MEDIAN")
+}
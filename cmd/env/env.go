@@ -0,0 +1,137 @@
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const envHelp = "usage: sqlpkg env [--shell=sh|fish|powershell] [--json]"
+
+// environment variables
+const (
+	dirEnvVar      = "SQLPKG_DIR"
+	lockfileEnvVar = "SQLPKG_LOCKFILE"
+)
+
+// shell syntaxes
+const (
+	shellSh         = "sh"
+	shellFish       = "fish"
+	shellPowerShell = "powershell"
+)
+
+// options are the env command options.
+type options struct {
+	// shell is the syntax of the export lines.
+	shell string
+	// json prints the environment as JSON instead.
+	json bool
+}
+
+// environment describes the active scope.
+type environment struct {
+	Scope      string             `json:"scope"`
+	Dir        string             `json:"dir"`
+	Lockfile   string             `json:"lockfile"`
+	Extensions []sqlpkg.Extension `json:"extensions"`
+}
+
+// Env prints the active scope information as shell export lines
+// (to use with eval) or as JSON.
+func Env(m *sqlpkg.Manager, args []string) error {
+	opts, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	env, err := readEnvironment(m)
+	if err != nil {
+		return err
+	}
+
+	if opts.json {
+		data, err := json.MarshalIndent(env, "", "    ")
+		if err != nil {
+			return err
+		}
+		m.Logger.Log(string(data))
+		return nil
+	}
+
+	vars := [][2]string{
+		{dirEnvVar, env.Dir},
+		{lockfileEnvVar, env.Lockfile},
+		{sqlpkg.ExtensionsEnvVar, sqlpkg.JoinPaths(env.Extensions)},
+	}
+	for _, v := range vars {
+		m.Logger.Log(exportLine(opts.shell, v[0], v[1]))
+	}
+	return nil
+}
+
+// parseArgs parses command options.
+func parseArgs(args []string) (*options, error) {
+	flags := flag.NewFlagSet("env", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	opts := &options{}
+	flags.StringVar(&opts.shell, "shell", shellSh, "shell syntax")
+	flags.BoolVar(&opts.json, "json", false, "print as JSON")
+	err := flags.Parse(args)
+	if err != nil || flags.NArg() != 0 {
+		return nil, errors.New(envHelp)
+	}
+
+	switch opts.shell {
+	case shellSh, shellFish, shellPowerShell:
+	default:
+		return nil, fmt.Errorf("unknown shell: %s", opts.shell)
+	}
+	return opts, nil
+}
+
+// readEnvironment gathers information about the active scope.
+func readEnvironment(m *sqlpkg.Manager) (*environment, error) {
+	env := &environment{Scope: "global"}
+	if m.Dir == "." {
+		env.Scope = "project"
+	}
+
+	var err error
+	env.Dir, err = filepath.Abs(m.RepoDir())
+	if err != nil {
+		return nil, err
+	}
+	env.Lockfile, err = filepath.Abs(m.LockfilePath())
+	if err != nil {
+		return nil, err
+	}
+	env.Extensions, err = m.SelectExtensions(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// exportLine returns the command that sets
+// the environment variable in the given shell.
+func exportLine(shell, name, value string) string {
+	switch shell {
+	case shellFish:
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		value = strings.ReplaceAll(value, `'`, `\'`)
+		return fmt.Sprintf("set -gx %s '%s';", name, value)
+	case shellPowerShell:
+		value = strings.ReplaceAll(value, `'`, `''`)
+		return fmt.Sprintf("$env:%s = '%s'", name, value)
+	default:
+		value = strings.ReplaceAll(value, `'`, `'\''`)
+		return fmt.Sprintf("export %s='%s'", name, value)
+	}
+}
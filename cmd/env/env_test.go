@@ -0,0 +1,103 @@
+package env
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+)
+
+func TestEnv(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("sh", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Env(m, nil)
+		if err != nil {
+			t.Fatalf("env error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "export SQLPKG_DIR='/")
+		mem.MustHave(t, ".sqlpkg'")
+		mem.MustHave(t, "export SQLPKG_LOCKFILE='/")
+		mem.MustHave(t, "sqlpkg.lock'")
+		mem.MustHave(t, "export SQLPKG_EXTENSIONS='/")
+		mem.MustHave(t, "nalgeon/example/example")
+		mem.MustHave(t, "sqlite/stmt/stmtvtab")
+	})
+	t.Run("fish", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Env(m, []string{"--shell=fish"})
+		if err != nil {
+			t.Fatalf("env error: %v", err)
+		}
+		mem.MustHave(t, "set -gx SQLPKG_DIR '/")
+		mem.MustHave(t, "set -gx SQLPKG_EXTENSIONS '/")
+	})
+	t.Run("powershell", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Env(m, []string{"--shell=powershell"})
+		if err != nil {
+			t.Fatalf("env error: %v", err)
+		}
+		mem.MustHave(t, "$env:SQLPKG_DIR = '/")
+		mem.MustHave(t, "$env:SQLPKG_LOCKFILE = '/")
+	})
+	t.Run("json", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Env(m, []string{"--json"})
+		if err != nil {
+			t.Fatalf("env error: %v", err)
+		}
+
+		var env environment
+		err = json.Unmarshal([]byte(mem.Lines[len(mem.Lines)-1]), &env)
+		if err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if env.Scope != "project" {
+			t.Errorf("unexpected scope: %s", env.Scope)
+		}
+		if !filepath.IsAbs(env.Dir) || filepath.Base(env.Dir) != ".sqlpkg" {
+			t.Errorf("unexpected dir: %s", env.Dir)
+		}
+		if filepath.Base(env.Lockfile) != "sqlpkg.lock" {
+			t.Errorf("unexpected lockfile: %s", env.Lockfile)
+		}
+		if len(env.Extensions) != 2 {
+			t.Fatalf("unexpected extensions: %v", env.Extensions)
+		}
+		if env.Extensions[0].Package != "nalgeon/example" || env.Extensions[0].Entrypoint != "sqlite3_example_init" {
+			t.Errorf("unexpected extension: %+v", env.Extensions[0])
+		}
+	})
+	t.Run("unknown shell", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Env(m, []string{"--shell=csh"})
+		if err == nil || !strings.Contains(err.Error(), "unknown shell") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func Test_exportLine(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  string
+	}{
+		{shellSh, `export NAME='it'\''s \here'`},
+		{shellFish, `set -gx NAME 'it\'s \\here';`},
+		{shellPowerShell, `$env:NAME = 'it''s \here'`},
+	}
+	for _, test := range tests {
+		got := exportLine(test.shell, "NAME", `it's \here`)
+		if got != test.want {
+			t.Errorf("%s: unexpected line %s", test.shell, got)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package exec
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"os"
+	osexec "os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const execHelp = "usage: sqlpkg exec [--only=pkg,...] -- <command> [args...]"
+
+// options are the exec command options.
+type options struct {
+	// only selects the packages to load (all if empty).
+	only cmd.ListFlag
+}
+
+// Exec runs a command with the installed extensions available.
+// Passes extension paths to any program via the SQLPKG_EXTENSIONS
+// environment variable, and preloads them into the sqlite3 shell
+// with -cmd ".load ..." arguments.
+//
+// Ctrl-C is left to the command (e.g. to interrupt a query in an interactive
+// sqlite3 session), so sqlpkg keeps running until the command exits.
+// If the command fails, returns an error with its exit code.
+func Exec(m *sqlpkg.Manager, args []string) error {
+	opts, args, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	exts, err := m.SelectExtensions(opts.only, nil)
+	if err != nil {
+		return err
+	}
+	m.Logger.Debug("running %s with %d extensions", args[0], len(exts))
+
+	command := buildCommand(args, exts)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+
+	// the terminal sends SIGINT to the command too,
+	// so sqlpkg only has to survive it
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	return exitStatus(command.Run())
+}
+
+// An exitError reports the exit code of a command
+// that has been terminated by a signal (128 + signal number).
+type exitError struct {
+	sig  syscall.Signal
+	code int
+}
+
+func (e *exitError) Error() string {
+	return "terminated by " + e.sig.String()
+}
+
+// ExitCode returns the exit code of the command.
+func (e *exitError) ExitCode() int {
+	return e.code
+}
+
+// exitStatus converts the error of a command terminated
+// by a signal to an error with the corresponding exit code.
+// Returns other errors as is.
+func exitStatus(err error) error {
+	var exitErr *osexec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return err
+	}
+	return &exitError{sig: status.Signal(), code: 128 + int(status.Signal())}
+}
+
+// parseArgs parses command options and returns the command to run.
+func parseArgs(args []string) (*options, []string, error) {
+	flags := flag.NewFlagSet("exec", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	opts := &options{}
+	flags.Var(&opts.only, "only", "packages to load")
+	err := flags.Parse(args)
+	if err != nil || flags.NArg() == 0 {
+		return nil, nil, errors.New(execHelp)
+	}
+	return opts, flags.Args(), nil
+}
+
+// buildCommand prepares the command that runs with the extensions.
+func buildCommand(args []string, exts []sqlpkg.Extension) *osexec.Cmd {
+	name, args := args[0], args[1:]
+	if isSQLite(name) {
+		loads := make([]string, 0, len(exts)*2)
+		for _, ext := range exts {
+			loads = append(loads, "-cmd", ext.LoadCommand())
+		}
+		args = append(loads, args...)
+	}
+	command := osexec.Command(name, args...)
+	command.Env = append(os.Environ(), sqlpkg.ExtensionsEnvVar+"="+sqlpkg.JoinPaths(exts))
+	return command
+}
+
+// isSQLite checks if the program is the sqlite3 shell.
+func isSQLite(name string) bool {
+	name = filepath.Base(name)
+	name = strings.TrimSuffix(strings.ToLower(name), ".exe")
+	return name == "sqlite3"
+}
@@ -0,0 +1,142 @@
+package exec
+
+import (
+	"errors"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+func TestExec(t *testing.T) {
+	if _, err := osexec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("env", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		output := filepath.Join(t.TempDir(), "env.txt")
+		args := []string{"--", "sh", "-c", `printf %s "$SQLPKG_EXTENSIONS" > "$0"`, output}
+		err := Exec(m, args)
+		if err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths := filepath.SplitList(string(data))
+		if len(paths) != 2 {
+			t.Fatalf("unexpected paths: %v", paths)
+		}
+		if !filepath.IsAbs(paths[0]) || !strings.Contains(paths[0], "nalgeon/example/example") {
+			t.Errorf("unexpected path: %s", paths[0])
+		}
+		if !strings.Contains(paths[1], "sqlite/stmt/stmtvtab") {
+			t.Errorf("unexpected path: %s", paths[1])
+		}
+	})
+	t.Run("only", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		output := filepath.Join(t.TempDir(), "only.txt")
+		args := []string{"--only=sqlite/*", "--", "sh", "-c", `printf %s "$SQLPKG_EXTENSIONS" > "$0"`, output}
+		err := Exec(m, args)
+		if err != nil {
+			t.Fatalf("exec error: %v", err)
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths := filepath.SplitList(string(data))
+		if len(paths) != 1 || !strings.Contains(paths[0], "sqlite/stmt/stmtvtab") {
+			t.Errorf("unexpected paths: %v", paths)
+		}
+	})
+	t.Run("exit code", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Exec(m, []string{"sh", "-c", "exit 3"})
+		var exitErr *osexec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exitErr.ExitCode() != 3 {
+			t.Errorf("unexpected exit code: %d", exitErr.ExitCode())
+		}
+	})
+	t.Run("signal", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Exec(m, []string{"sh", "-c", "kill -TERM $$"})
+		var exitErr interface{ ExitCode() int }
+		if !errors.As(err, &exitErr) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exitErr.ExitCode() != 128+int(syscall.SIGTERM) {
+			t.Errorf("unexpected exit code: %d", exitErr.ExitCode())
+		}
+	})
+	t.Run("help", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Exec(m, []string{"--only=sqlite/*"})
+		if err == nil || err.Error() != execHelp {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func Test_buildCommand(t *testing.T) {
+	exts := []sqlpkg.Extension{
+		{Package: "nalgeon/example", Path: "/ext/example.so", Entrypoint: "sqlite3_example_init"},
+		{Package: "sqlite/stmt", Path: "/ext/my stmt.so"},
+	}
+
+	t.Run("sqlite3", func(t *testing.T) {
+		command := buildCommand([]string{"sqlite3", "app.db"}, exts)
+		want := []string{
+			"sqlite3",
+			"-cmd", ".load /ext/example.so sqlite3_example_init",
+			"-cmd", ".load '/ext/my stmt.so'",
+			"app.db",
+		}
+		if strings.Join(command.Args, "|") != strings.Join(want, "|") {
+			t.Errorf("unexpected args: %q", command.Args)
+		}
+		env := command.Env[len(command.Env)-1]
+		sep := string(os.PathListSeparator)
+		if env != "SQLPKG_EXTENSIONS=/ext/example.so"+sep+"/ext/my stmt.so" {
+			t.Errorf("unexpected env: %s", env)
+		}
+	})
+	t.Run("other", func(t *testing.T) {
+		command := buildCommand([]string{"python", "app.py"}, exts)
+		want := []string{"python", "app.py"}
+		if strings.Join(command.Args, "|") != strings.Join(want, "|") {
+			t.Errorf("unexpected args: %q", command.Args)
+		}
+	})
+}
+
+func Test_isSQLite(t *testing.T) {
+	tests := map[string]bool{
+		"sqlite3":                true,
+		"/usr/local/bin/sqlite3": true,
+		"SQLITE3.EXE":            true,
+		"sqlite3-wrapper":        false,
+		"python":                 false,
+	}
+	for name, want := range tests {
+		got := isSQLite(name)
+		if got != want {
+			t.Errorf("%s: unexpected value %v", name, got)
+		}
+	}
+}
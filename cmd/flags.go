@@ -0,0 +1,22 @@
+// Command-line flag helpers shared by commands.
+package cmd
+
+import "strings"
+
+// ListFlag is a comma-separated list flag value.
+// Can be specified multiple times.
+type ListFlag []string
+
+func (l *ListFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *ListFlag) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
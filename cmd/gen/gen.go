@@ -0,0 +1,136 @@
+package gen
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const genHelp = "usage: sqlpkg gen [--output=path] [--package=name] go|python|node"
+
+// options are the gen command options.
+type options struct {
+	// output is the path to the generated file (stdout if empty).
+	output string
+	// pkgName is the Go package name.
+	pkgName string
+	// platform is the platform the extensions are installed for.
+	platform sqlpkg.Platform
+}
+
+// An entry is an extension to list in the generated source.
+type entry struct {
+	Package    string
+	Version    string
+	Path       string
+	Entrypoint string
+}
+
+// renderers generate source code by language.
+var renderers = map[string]func(entries []entry, opts *options) ([]byte, error){
+	"go":     renderGo,
+	"python": renderPython,
+	"node":   renderNode,
+}
+
+// Gen generates a source file that maps package names to extension paths
+// and entry points for the current scope and platform.
+func Gen(m *sqlpkg.Manager, args []string) error {
+	opts, lang, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	opts.platform = m.Platform
+
+	entries, err := readEntries(m)
+	if err != nil {
+		return err
+	}
+
+	src, err := renderers[lang](entries, opts)
+	if err != nil {
+		return fmt.Errorf("failed to generate %s source: %w", lang, err)
+	}
+
+	if opts.output == "" {
+		_, err = m.Logger.Output().Write(src)
+		return err
+	}
+	err = os.WriteFile(opts.output, src, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.output, err)
+	}
+	m.Logger.Log("✓ wrote %d extensions to %s", len(entries), opts.output)
+	return nil
+}
+
+// parseArgs parses command options and returns the target language.
+func parseArgs(args []string) (*options, string, error) {
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	opts := &options{}
+	flags.StringVar(&opts.output, "output", "", "path to the generated file")
+	flags.StringVar(&opts.pkgName, "package", "extensions", "Go package name")
+	err := flags.Parse(args)
+	if err != nil || flags.NArg() != 1 {
+		return nil, "", errors.New(genHelp)
+	}
+
+	lang := flags.Arg(0)
+	if _, ok := renderers[lang]; !ok {
+		return nil, "", fmt.Errorf("unknown language: %s", lang)
+	}
+	return opts, lang, nil
+}
+
+// readEntries returns extensions of the packages listed in the lockfile,
+// sorted by package name. Uses the installed specs to find extension files,
+// and skips packages that are not installed or have no files for the platform.
+func readEntries(m *sqlpkg.Manager) ([]entry, error) {
+	lck, err := m.ReadLockfile()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(lck.Packages))
+	for fullName := range lck.Packages {
+		names = append(names, fullName)
+	}
+	sort.Strings(names)
+
+	entries := []entry{}
+	for _, fullName := range names {
+		pkg := m.ReadInstalledSpec(fullName)
+		if pkg == nil {
+			m.Logger.Debug("skipping %s: package is not installed", fullName)
+			continue
+		}
+		exts, err := m.FindExtensions(pkg)
+		if err != nil {
+			m.Logger.Debug("skipping %s: %s", fullName, err)
+			continue
+		}
+		if len(exts) > 1 {
+			m.Logger.Debug("%s has %d extension files, using %s", fullName, len(exts), exts[0].Path)
+		}
+		path, err := filepath.Abs(exts[0].Path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry{
+			Package:    fullName,
+			Version:    pkg.Version,
+			Path:       path,
+			Entrypoint: exts[0].Entrypoint,
+		})
+	}
+
+	m.Logger.Debug("found %d extensions for %s/%s", len(entries), m.Platform.OS, m.Platform.Arch)
+	return entries, nil
+}
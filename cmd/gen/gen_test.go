@@ -0,0 +1,135 @@
+package gen
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+)
+
+func TestGen(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("go", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Gen(m, []string{"--package=sqlext", "go"})
+		if err != nil {
+			t.Fatalf("gen error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "package sqlext")
+		mem.MustHave(t, `NalgeonExampleEntrypoint = "sqlite3_example_init"`)
+		mem.MustHave(t, `"nalgeon/example": {Version: "0.2.0", Path: NalgeonExamplePath`)
+		mem.MustHave(t, `"sqlite/stmt":     {Version: ""`)
+
+		src := mem.Lines[len(mem.Lines)-1]
+		if strings.Contains(src, "nalgeon/missing") || strings.Contains(src, "nalgeon/broken") {
+			t.Error("unexpected packages without extension files")
+		}
+		_, err = parser.ParseFile(token.NewFileSet(), "extensions.go", src, 0)
+		if err != nil {
+			t.Errorf("invalid go source: %v", err)
+		}
+	})
+	t.Run("python", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Gen(m, []string{"python"})
+		if err != nil {
+			t.Fatalf("gen error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "# Platform: "+m.Platform.OS+"/"+m.Platform.Arch)
+		mem.MustHave(t, "EXTENSIONS = {")
+		mem.MustHave(t, `"entrypoint": "sqlite3_example_init",`)
+		mem.MustHave(t, `"entrypoint": None,`)
+	})
+	t.Run("node", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Gen(m, []string{"node"})
+		if err != nil {
+			t.Fatalf("gen error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "const EXTENSIONS = Object.freeze({")
+		mem.MustHave(t, `entrypoint: "sqlite3_example_init",`)
+		mem.MustHave(t, "entrypoint: null,")
+		mem.MustHave(t, "module.exports = { EXTENSIONS };")
+	})
+	t.Run("output", func(t *testing.T) {
+		dir := t.TempDir()
+		firstPath := filepath.Join(dir, "first.py")
+		secondPath := filepath.Join(dir, "second.py")
+		mem := logx.Mock(m.Logger)
+		err := Gen(m, []string{"--output=" + firstPath, "python"})
+		if err != nil {
+			t.Fatalf("gen error: %v", err)
+		}
+		mem.MustHave(t, "✓ wrote 2 extensions to "+firstPath)
+
+		err = Gen(m, []string{"--output=" + secondPath, "python"})
+		if err != nil {
+			t.Fatalf("gen error: %v", err)
+		}
+		first, _ := os.ReadFile(firstPath)
+		second, _ := os.ReadFile(secondPath)
+		if len(first) == 0 || string(first) != string(second) {
+			t.Error("generated files differ")
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Gen(m, []string{"rust"})
+		if err == nil || !strings.Contains(err.Error(), "unknown language") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = Gen(m, nil)
+		if err == nil || err.Error() != genHelp {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func Test_goIdent(t *testing.T) {
+	tests := map[string]string{
+		"nalgeon/text":      "NalgeonText",
+		"asg017/sqlite-vec": "Asg017SqliteVec",
+		"nalgeon/text_ext":  "NalgeonTextExt",
+		"0x/uuid":           "X0xUuid",
+	}
+	for fullName, want := range tests {
+		got := goIdent(fullName)
+		if got != want {
+			t.Errorf("%s: unexpected value %s", fullName, got)
+		}
+	}
+}
+
+func Test_goIdents(t *testing.T) {
+	t.Run("unique", func(t *testing.T) {
+		entries := []entry{{Package: "nalgeon/text"}, {Package: "nalgeon/text-ext"}}
+		idents, err := goIdents(entries)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if idents[0] != "NalgeonText" || idents[1] != "NalgeonTextExt" {
+			t.Errorf("unexpected idents: %v", idents)
+		}
+	})
+	t.Run("collision", func(t *testing.T) {
+		entries := []entry{{Package: "nalgeon/text-ext"}, {Package: "nalgeon/text_ext"}}
+		_, err := goIdents(entries)
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "NalgeonTextExt") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
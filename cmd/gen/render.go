@@ -0,0 +1,154 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// header is the first line of every generated file.
+const header = "Code generated by sqlpkg gen; DO NOT EDIT."
+
+// renderGo generates a Go file with path and entry point constants
+// for each package, and a map of package names to extensions.
+func renderGo(entries []entry, opts *options) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n", header)
+	fmt.Fprintf(&b, "// Platform: %s/%s\n\n", opts.platform.OS, opts.platform.Arch)
+	fmt.Fprintf(&b, "// Package %s lists SQLite extensions installed with sqlpkg.\n", opts.pkgName)
+	fmt.Fprintf(&b, "package %s\n\n", opts.pkgName)
+
+	b.WriteString("// An Extension is an extension library file and its entry point.\n")
+	b.WriteString("// Entrypoint is empty if it's unknown.\n")
+	b.WriteString("type Extension struct {\n")
+	b.WriteString("Version string\nPath string\nEntrypoint string\n}\n\n")
+
+	idents, err := goIdents(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) != 0 {
+		b.WriteString("// Extension files and entry points by package.\n")
+		b.WriteString("const (\n")
+		for i, e := range entries {
+			ident := idents[i]
+			fmt.Fprintf(&b, "%sPath = %s\n", ident, strconv.Quote(e.Path))
+			fmt.Fprintf(&b, "%sEntrypoint = %s\n", ident, strconv.Quote(e.Entrypoint))
+		}
+		b.WriteString(")\n\n")
+	}
+
+	b.WriteString("// Extensions maps package names (owner/name) to extensions.\n")
+	b.WriteString("var Extensions = map[string]Extension{\n")
+	for i, e := range entries {
+		ident := idents[i]
+		fmt.Fprintf(&b, "%s: {Version: %s, Path: %sPath, Entrypoint: %sEntrypoint},\n",
+			strconv.Quote(e.Package), strconv.Quote(e.Version), ident, ident)
+	}
+	b.WriteString("}\n")
+
+	return format.Source([]byte(b.String()))
+}
+
+// renderPython generates a Python module with
+// a dict of package names to extensions.
+func renderPython(entries []entry, opts *options) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", header)
+	fmt.Fprintf(&b, "# Platform: %s/%s\n", opts.platform.OS, opts.platform.Arch)
+	b.WriteString("\"\"\"SQLite extensions installed with sqlpkg.\"\"\"\n\n")
+	b.WriteString("# Maps package names (owner/name) to extensions.\n")
+	b.WriteString("# The entrypoint is None if it's unknown.\n")
+	b.WriteString("EXTENSIONS = {\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "    %s: {\n", jsString(e.Package))
+		fmt.Fprintf(&b, "        \"version\": %s,\n", jsString(e.Version))
+		fmt.Fprintf(&b, "        \"path\": %s,\n", jsString(e.Path))
+		fmt.Fprintf(&b, "        \"entrypoint\": %s,\n", optional(e.Entrypoint, "None"))
+		b.WriteString("    },\n")
+	}
+	b.WriteString("}\n")
+	return []byte(b.String()), nil
+}
+
+// renderNode generates a CommonJS module that exports
+// an object of package names to extensions.
+func renderNode(entries []entry, opts *options) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// %s\n", header)
+	fmt.Fprintf(&b, "// Platform: %s/%s\n", opts.platform.OS, opts.platform.Arch)
+	b.WriteString("// SQLite extensions installed with sqlpkg.\n\n")
+	b.WriteString("\"use strict\";\n\n")
+	b.WriteString("// Maps package names (owner/name) to extensions.\n")
+	b.WriteString("// The entrypoint is null if it's unknown.\n")
+	b.WriteString("const EXTENSIONS = Object.freeze({\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "    %s: Object.freeze({\n", jsString(e.Package))
+		fmt.Fprintf(&b, "        version: %s,\n", jsString(e.Version))
+		fmt.Fprintf(&b, "        path: %s,\n", jsString(e.Path))
+		fmt.Fprintf(&b, "        entrypoint: %s,\n", optional(e.Entrypoint, "null"))
+		b.WriteString("    }),\n")
+	}
+	b.WriteString("});\n\n")
+	b.WriteString("module.exports = { EXTENSIONS };\n")
+	return []byte(b.String()), nil
+}
+
+// goIdents returns Go identifiers for the entries' packages.
+// Fails if two packages map to the same identifier
+// (e.g. nalgeon/text-ext and nalgeon/text_ext).
+func goIdents(entries []entry) ([]string, error) {
+	idents := make([]string, len(entries))
+	seen := map[string]string{}
+	for i, e := range entries {
+		ident := goIdent(e.Package)
+		if other, ok := seen[ident]; ok {
+			return nil, fmt.Errorf("packages %s and %s have the same Go name %s", other, e.Package, ident)
+		}
+		seen[ident] = e.Package
+		idents[i] = ident
+	}
+	return idents, nil
+}
+
+// goIdent converts the package name to an exported Go identifier,
+// e.g. nalgeon/text-ext -> NalgeonTextExt.
+func goIdent(fullName string) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range fullName {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(c) {
+			b.WriteRune('X')
+		}
+		if upper {
+			c = unicode.ToUpper(c)
+			upper = false
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// jsString returns the value as a double-quoted string literal
+// that is valid in both JavaScript and Python.
+func jsString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// optional returns the value as a string literal,
+// or the null literal if the value is empty.
+func optional(s, null string) string {
+	if s == "" {
+		return null
+	}
+	return jsString(s)
+}
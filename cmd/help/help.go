@@ -12,31 +12,40 @@ import (
 const help = "usage: sqlpkg help"
 
 var commandsHelp = map[string]string{
+	"doctor":    "Check installed packages for problems",
+	"env":       "Print scope environment variables",
+	"exec":      "Run command with installed extensions",
+	"gen":       "Generate source file with extension paths",
 	"help":      "Display help",
 	"info":      "Display package information",
 	"init":      "Init project scope",
 	"install":   "Install packages",
 	"list":      "List installed packages",
+	"loader":    "Write init script that loads extensions",
+	"provides":  "Find packages that define SQL function",
+	"search":    "Search for packages in registries",
+	"trust":     "Manage trusted signing keys",
 	"uninstall": "Uninstall package",
 	"update":    "Update installed packages",
+	"verify":    "Verify installed package files",
 	"version":   "Display version",
 	"which":     "Display path to extension file",
 }
 
 // Help prints available commands.
-func Help(args []string) error {
+func Help(logger *logx.Logger, args []string) error {
 	if len(args) != 0 {
 		return errors.New(help)
 	}
 
-	logx.Log("sqlpkg is a package manager for installing and updating SQLite extensions.\n")
-	logx.Log("USAGE")
-	logx.Log("  sqlpkg [global-options] <command> [arguments]\n")
-	logx.Log("GLOBAL OPTIONS")
-	logx.Log("  -v  verbose output\n")
-	logx.Log("COMMANDS")
+	logger.Log("sqlpkg is a package manager for installing and updating SQLite extensions.\n")
+	logger.Log("USAGE")
+	logger.Log("  sqlpkg [global-options] <command> [arguments]\n")
+	logger.Log("GLOBAL OPTIONS")
+	logger.Log("  -v  verbose output\n")
+	logger.Log("COMMANDS")
 
-	w := tabwriter.NewWriter(logx.Output(), 0, 4, 0, ' ', 0)
+	w := tabwriter.NewWriter(logger.Output(), 0, 4, 0, ' ', 0)
 	for _, cmd := range sortedCommands() {
 		fmt.Fprintln(w, "  ", cmd, "\t", commandsHelp[cmd])
 	}
@@ -3,17 +3,17 @@ package help
 import (
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/logx"
 )
 
 func TestHelp(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	mem := logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	mem := logx.Mock(m.Logger)
 
 	args := []string{}
-	err := Help(args)
+	err := Help(m.Logger, args)
 	if err != nil {
 		t.Fatalf("help error: %v", err)
 	}
@@ -27,6 +27,13 @@ func TestHelp(t *testing.T) {
 	mem.MustHave(t, "init")
 	mem.MustHave(t, "info")
 	mem.MustHave(t, "which")
+	mem.MustHave(t, "trust")
+	mem.MustHave(t, "verify")
+	mem.MustHave(t, "doctor")
+	mem.MustHave(t, "loader")
+	mem.MustHave(t, "exec")
+	mem.MustHave(t, "env")
+	mem.MustHave(t, "gen")
 	mem.MustHave(t, "help")
 	mem.MustHave(t, "version")
 }
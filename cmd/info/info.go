@@ -1,39 +1,48 @@
 package info
 
 import (
+	"context"
 	"errors"
+	"flag"
+	"io"
 	"strings"
 
-	"sqlpkg.org/cli/cmd"
-	"sqlpkg.org/cli/fileio"
-	"sqlpkg.org/cli/logx"
-	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
-const infoHelp = "usage: sqlpkg info <package>"
+const infoHelp = "usage: sqlpkg info [--symbols] <package>"
 
 // Info prints information about the package (installed or not).
-func Info(args []string) error {
-	if len(args) != 1 {
+// With --symbols, also lists the SQL symbols the package defines.
+func Info(ctx context.Context, m *sqlpkg.Manager, args []string) error {
+	flags := flag.NewFlagSet("info", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	symbols := flags.Bool("symbols", false, "list package symbols")
+	err := flags.Parse(args)
+	if err != nil || flags.NArg() != 1 {
 		return errors.New(infoHelp)
 	}
 
-	path := args[0]
-	pkg, err := cmd.FindSpec(path)
+	path := flags.Arg(0)
+	res, err := m.Info(ctx, path)
 	if err != nil {
-		logx.Debug(err.Error())
-		logx.Log("package not found")
+		m.Logger.Debug(err.Error())
+		m.Logger.Log("package not found")
 		return nil
 	}
 
-	lines := prepareInfo(pkg)
-	logx.Log(strings.Join(lines, "\n"))
+	lines := prepareInfo(res)
+	if *symbols {
+		lines = append(lines, prepareSymbols(res)...)
+	}
+	m.Logger.Log(strings.Join(lines, "\n"))
 
 	return nil
 }
 
 // prepareInfo returns detailed package description.
-func prepareInfo(pkg *spec.Package) []string {
+func prepareInfo(res *sqlpkg.InfoResult) []string {
+	pkg := res.Package
 	lines := []string{}
 
 	header := pkg.FullName()
@@ -55,7 +64,10 @@ func prepareInfo(pkg *spec.Package) []string {
 	if pkg.License != "" {
 		lines = append(lines, "license: "+pkg.License)
 	}
-	if isInstalled(pkg) {
+	if pkg.Registry != "" {
+		lines = append(lines, "registry: "+pkg.Registry)
+	}
+	if res.Installed {
 		lines = append(lines, "✓ installed")
 	} else {
 		lines = append(lines, "✘ not installed")
@@ -63,8 +75,14 @@ func prepareInfo(pkg *spec.Package) []string {
 	return lines
 }
 
-// isInstalled checks if there is a local package installed.
-func isInstalled(pkg *spec.Package) bool {
-	path := spec.Path(cmd.WorkDir, pkg.Owner, pkg.Name)
-	return fileio.Exists(path)
+// prepareSymbols returns the list of package symbols.
+func prepareSymbols(res *sqlpkg.InfoResult) []string {
+	if len(res.Package.Symbols) == 0 {
+		return []string{"symbols: none listed"}
+	}
+	lines := []string{"symbols:"}
+	for _, sym := range res.Package.Symbols {
+		lines = append(lines, "  "+sym)
+	}
+	return lines
 }
@@ -1,20 +1,25 @@
 package info
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
 )
 
 func TestInfo(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
-	mem := logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
 
 	args := []string{"nalgeon/example"}
-	err := Info(args)
+	err := Info(ctx, m, args)
 	if err != nil {
 		t.Fatalf("info error: %v", err)
 	}
@@ -26,3 +31,49 @@ func TestInfo(t *testing.T) {
 	mem.MustHave(t, "license: MIT")
 	mem.MustHave(t, "✓ installed")
 }
+
+func TestSymbols(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
+
+	args := []string{"--symbols", "nalgeon/example"}
+	err := Info(ctx, m, args)
+	if err != nil {
+		t.Fatalf("info error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, "symbols:\n  example_fn\n  example_version")
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+
+	cfg := &config.Config{
+		Registries: []spec.Registry{
+			{Name: "internal", Dir: filepath.Join("testdata", "registry")},
+		},
+	}
+	err := m.SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig: unexpected error %v", err)
+	}
+	mem := logx.Mock(m.Logger)
+
+	args := []string{"nalgeon/example"}
+	err = Info(ctx, m, args)
+	if err != nil {
+		t.Fatalf("info error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, "nalgeon/example@0.2.0")
+	mem.MustHave(t, "Vetted example extension")
+	mem.MustHave(t, "registry: internal")
+	mem.MustHave(t, "✘ not installed")
+}
@@ -13,7 +13,7 @@ import (
 const initHelp = "usage: sqlpkg init"
 
 // Init creates an empty local package repository.
-func Init(args []string) error {
+func Init(logger *logx.Logger, args []string) error {
 	if len(args) != 0 {
 		return errors.New(initHelp)
 	}
@@ -27,6 +27,6 @@ func Init(args []string) error {
 		return fmt.Errorf("failed to create a project scope: %w", err)
 	}
 
-	logx.Log("✓ created a project scope")
+	logger.Log("✓ created a project scope")
 	return nil
 }
@@ -4,17 +4,17 @@ import (
 	"strings"
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/logx"
 )
 
 func TestInit(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	mem := logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	mem := logx.Mock(m.Logger)
 
 	args := []string{}
-	err := Init(args)
+	err := Init(m.Logger, args)
 	if err != nil {
 		t.Fatalf("init error: %v", err)
 	}
@@ -24,13 +24,13 @@ func TestInit(t *testing.T) {
 }
 
 func TestAlreadyExists(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
 
 	args := []string{}
-	_ = Init(args)
-	err := Init(args)
+	_ = Init(m.Logger, args)
+	err := Init(m.Logger, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -1,188 +1,113 @@
 package install
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 
 	"sqlpkg.org/cli/cmd"
-	"sqlpkg.org/cli/logx"
-	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
-const installHelp = "usage: sqlpkg install [package]"
-
-// InstallAll installs all packages from the lockfile.
-func InstallAll(args []string) error {
-	cmd.PrintScope()
-
-	lck, err := cmd.ReadLockfile()
-	if err != nil {
-		return err
-	}
-	logx.Debug("loaded the lockfile with %d packages", len(lck.Packages))
-
-	if len(lck.Packages) == 0 {
-		logx.Log("no packages found in the lockfile")
-		return nil
-	}
-
-	errCount := 0
-	for _, pkg := range lck.Packages {
-		err = installLockedPackage(pkg)
-		if err != nil {
-			errCount += 1
-			logx.Log("! %s", err)
-		}
-	}
-
-	if errCount > 0 {
-		return fmt.Errorf("failed to install %d packages", errCount)
-	}
-	logx.Log("installed %d packages", len(lck.Packages))
-	return nil
-}
+const installHelp = "usage: sqlpkg install [--require-checksums] [package]"
 
 // Install installs a new package or updates an existing one.
-func Install(args []string) error {
-	if len(args) != 1 {
-		return errors.New(installHelp)
-	}
-
-	cmd.PrintScope()
-
-	path := args[0]
-	err := installPackage(path)
-	return err
-}
-
-// installPackage installs a package using a specfile from a given path.
-func installPackage(path string) error {
-	logx.Log("> installing %s...", path)
-
-	pkg, err := cmd.ReadSpec(path)
+// Installs all packages from the lockfile if the package is not specified.
+func Install(ctx context.Context, m *sqlpkg.Manager, args []string) error {
+	opts, args, err := parseArgs(args)
 	if err != nil {
 		return err
 	}
-
-	err = cmd.ResolveVersion(pkg)
-	if err != nil {
-		return err
-	}
-
-	if !cmd.HasNewVersion(pkg) {
-		logx.Log("✓ already at the latest version")
-		return nil
-	}
-
-	err = cmd.ReadChecksums(pkg)
-	if err != nil {
-		return err
-	}
-
-	assetPath, err := cmd.BuildAssetPath(pkg)
-	if err != nil {
-		return err
-	}
-
-	asset, err := cmd.DownloadAsset(pkg, assetPath)
-	if err != nil {
-		return err
-	}
-
-	err = cmd.ValidateAsset(pkg, asset)
-	if err != nil {
-		return err
+	if len(args) > 1 {
+		return errors.New(installHelp)
 	}
-
-	err = cmd.UnpackAsset(pkg, asset)
-	if err != nil {
-		return err
+	if len(args) == 0 {
+		return installAll(ctx, m, opts)
 	}
 
-	err = cmd.InstallFiles(pkg, asset)
-	if err != nil {
-		return err
-	}
-
-	err = cmd.DequarantineFiles(pkg)
-	if err != nil {
-		return err
-	}
+	cmd.PrintScope(m)
 
-	lck, err := cmd.ReadLockfile()
+	path := args[0]
+	m.Logger.Log("> installing %s...", path)
+	res, err := m.Install(ctx, path, opts)
 	if err != nil {
 		return err
 	}
+	printResult(m, res)
+	return m.RegenerateLoader()
+}
 
-	err = cmd.AddToLockfile(lck, pkg)
+// parseArgs parses command options and returns the remaining arguments.
+func parseArgs(args []string) (sqlpkg.InstallOptions, []string, error) {
+	flags := flag.NewFlagSet("install", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	requireChecksums := flags.Bool("require-checksums", false, "fail if an asset has no verifiable checksum")
+	err := flags.Parse(args)
 	if err != nil {
-		return err
+		return sqlpkg.InstallOptions{}, nil, errors.New(installHelp)
 	}
-
-	dir := spec.Dir(cmd.WorkDir, pkg.Owner, pkg.Name)
-	logx.Log("✓ installed package %s to %s", pkg.FullName(), dir)
-	return nil
+	opts := sqlpkg.InstallOptions{RequireChecksums: *requireChecksums}
+	return opts, flags.Args(), nil
 }
 
-// installLockedPackage installs a specific version of a package from the lockfile.
-func installLockedPackage(lckPkg *spec.Package) error {
-	path := lckPkg.Specfile
-	if path == "" {
-		logx.Debug("missing specfile for %s, falling back to name/owner", lckPkg.FullName())
-		path = lckPkg.FullName()
-	}
-
-	logx.Log("> installing %s...", path)
+// installAll installs all packages from the lockfile.
+func installAll(ctx context.Context, m *sqlpkg.Manager, opts sqlpkg.InstallOptions) error {
+	cmd.PrintScope(m)
 
-	pkg, err := cmd.ReadSpec(path)
+	lck, err := m.ReadLockfile()
 	if err != nil {
 		return err
 	}
+	m.Logger.Debug("loaded the lockfile with %d packages", len(lck.Packages))
 
-	// lock the version
-	logx.Debug("locked version = %s", lckPkg.Version)
-	pkg.Version = lckPkg.Version
-	pkg.Assets = lckPkg.Assets
-
-	if !cmd.HasNewVersion(pkg) {
-		logx.Log("✓ already at the %s version", pkg.Version)
+	if len(lck.Packages) == 0 {
+		m.Logger.Log("no packages found in the lockfile")
 		return nil
 	}
 
-	assetPath, err := cmd.BuildAssetPath(pkg)
-	if err != nil {
-		return err
-	}
-
-	asset, err := cmd.DownloadAsset(pkg, assetPath)
-	if err != nil {
-		return err
-	}
-
-	err = cmd.ValidateAsset(pkg, asset)
-	if err != nil {
-		return err
+	errCount := 0
+	for _, lckPkg := range lck.Packages {
+		if ctx.Err() != nil {
+			// interrupted, don't bother with the rest
+			return ctx.Err()
+		}
+		path := lckPkg.Specfile
+		if path == "" {
+			path = lckPkg.FullName()
+		}
+		m.Logger.Log("> installing %s...", path)
+		res, err := m.InstallLocked(ctx, lckPkg, opts)
+		if err != nil {
+			errCount += 1
+			m.Logger.Log("! %s", err)
+			continue
+		}
+		if !res.Installed {
+			m.Logger.Log("✓ already at the %s version", res.Package.Version)
+			continue
+		}
+		printResult(m, res)
 	}
 
-	err = cmd.UnpackAsset(pkg, asset)
+	err = m.RegenerateLoader()
 	if err != nil {
 		return err
 	}
 
-	err = cmd.InstallFiles(pkg, asset)
-	if err != nil {
-		return err
+	if errCount > 0 {
+		return fmt.Errorf("failed to install %d packages", errCount)
 	}
+	m.Logger.Log("installed %d packages", len(lck.Packages))
+	return nil
+}
 
-	err = cmd.DequarantineFiles(pkg)
-	if err != nil {
-		return err
+// printResult prints the outcome of a package install.
+func printResult(m *sqlpkg.Manager, res *sqlpkg.InstallResult) {
+	if !res.Installed {
+		m.Logger.Log("✓ already at the latest version")
+		return
 	}
-
-	// no need to add the package to the lockfile,
-	// it's already there
-
-	dir := spec.Dir(cmd.WorkDir, pkg.Owner, pkg.Name)
-	logx.Log("✓ installed package %s to %s", pkg.FullName(), dir)
-	return nil
+	m.Logger.Log("✓ installed package %s to %s", res.Package.FullName(), res.Dir)
 }
@@ -1,23 +1,27 @@
 package install
 
 import (
+	"context"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/config"
 	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/lockfile"
 	"sqlpkg.org/cli/logx"
 )
 
 func TestFull(t *testing.T) {
-	repoDir, lockPath := cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	mem := logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	mem := logx.Mock(m.Logger)
 
-	args := []string{filepath.Join(cmd.WorkDir, "testdata", "full", "sqlpkg.json")}
-	err := Install(args)
+	args := []string{filepath.Join(m.Dir, "testdata", "full", "sqlpkg.json")}
+	err := Install(ctx, m, args)
 	if err != nil {
 		t.Fatalf("installation error: %v", err)
 	}
@@ -33,17 +37,44 @@ func TestFull(t *testing.T) {
 	mem.MustHave(t, "added package to the lockfile")
 	mem.MustHave(t, "installed package nalgeon/example")
 
-	validatePackage(t, repoDir, lockPath, "nalgeon", "example")
+	validatePackage(t, m.RepoDir(), m.LockfilePath(), "nalgeon", "example")
+}
+
+func TestSigned(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	mem := logx.Mock(m.Logger)
+
+	args := []string{filepath.Join(m.Dir, "testdata", "signed", "sqlpkg.json")}
+	err := Install(ctx, m, args)
+	if err != nil {
+		t.Fatalf("installation error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, "trusting package key on first use")
+	mem.MustHave(t, "checksum signature is valid")
+	mem.MustHave(t, "asset checksum is valid")
+	mem.MustHave(t, "installed package nalgeon/example")
+
+	validatePackage(t, m.RepoDir(), m.LockfilePath(), "nalgeon", "example")
+
+	lck, _ := lockfile.ReadLocal(m.LockfilePath())
+	if lck.Packages["nalgeon/example"].Publickey == "" {
+		t.Fatal("package key is not pinned in the lockfile")
+	}
 }
 
 func TestLockfile(t *testing.T) {
-	repoDir, lockPath := cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "lockfile")
-	mem := logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "lockfile")
+	mem := logx.Mock(m.Logger)
 
 	args := []string{}
-	err := InstallAll(args)
+	err := Install(ctx, m, args)
 	if err != nil {
 		t.Fatalf("installation error: %v", err)
 	}
@@ -54,16 +85,17 @@ func TestLockfile(t *testing.T) {
 	mem.MustHave(t, "locked version = 0.1.0")
 	mem.MustHave(t, "installed package nalgeon/example")
 
-	validatePackage(t, repoDir, lockPath, "nalgeon", "example")
+	validatePackage(t, m.RepoDir(), m.LockfilePath(), "nalgeon", "example")
 }
 
 func TestMinimal(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	mem := logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	mem := logx.Mock(m.Logger)
 
-	args := []string{filepath.Join(cmd.WorkDir, "testdata", "minimal", "sqlpkg.json")}
-	err := Install(args)
+	args := []string{filepath.Join(m.Dir, "testdata", "minimal", "sqlpkg.json")}
+	err := Install(ctx, m, args)
 	if err != nil {
 		t.Fatalf("installation error: %v", err)
 	}
@@ -78,14 +110,77 @@ func TestMinimal(t *testing.T) {
 	mem.MustHave(t, "installed package nalgeon/example")
 }
 
+func TestRequireChecksums(t *testing.T) {
+	ctx := context.Background()
+	t.Run("flag", func(t *testing.T) {
+		m := sqlpkgtest.SetupRepo(t)
+		defer sqlpkgtest.TeardownRepo(t)
+		logx.Mock(m.Logger)
+
+		args := []string{"--require-checksums", filepath.Join(m.Dir, "testdata", "minimal", "sqlpkg.json")}
+		err := Install(ctx, m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "checksums are required") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("config", func(t *testing.T) {
+		m := sqlpkgtest.SetupRepo(t)
+		defer sqlpkgtest.TeardownRepo(t)
+		logx.Mock(m.Logger)
+
+		err := os.MkdirAll(m.RepoDir(), 0755)
+		if err != nil {
+			t.Fatalf("os.MkdirAll: %v", err)
+		}
+		err = os.WriteFile(config.Path(m.Dir), []byte(`{"require_checksums": true}`), 0644)
+		if err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+
+		args := []string{filepath.Join(m.Dir, "testdata", "minimal", "sqlpkg.json")}
+		err = Install(ctx, m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "checksums are required") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("verified", func(t *testing.T) {
+		m := sqlpkgtest.SetupRepo(t)
+		defer sqlpkgtest.TeardownRepo(t)
+		logx.Mock(m.Logger)
+
+		args := []string{"--require-checksums", filepath.Join(m.Dir, "testdata", "full", "sqlpkg.json")}
+		err := Install(ctx, m, args)
+		if err != nil {
+			t.Fatalf("installation error: %v", err)
+		}
+	})
+}
+
+func TestInvalidArgs(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.Manager()
+	logx.Mock(m.Logger)
+	err := Install(ctx, m, []string{"--unknown", "nalgeon/example"})
+	if err == nil || !strings.HasPrefix(err.Error(), "usage") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestAlreadyInstalled(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "installed")
-	mem := logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "installed")
+	mem := logx.Mock(m.Logger)
 
-	args := []string{filepath.Join(cmd.WorkDir, "testdata", "installed", "sqlpkg.json")}
-	err := Install(args)
+	args := []string{filepath.Join(m.Dir, "testdata", "installed", "sqlpkg.json")}
+	err := Install(ctx, m, args)
 	if err != nil {
 		t.Fatalf("installation error: %v", err)
 	}
@@ -95,12 +190,13 @@ func TestAlreadyInstalled(t *testing.T) {
 }
 
 func TestInvalidChecksum(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
 
-	args := []string{filepath.Join(cmd.WorkDir, "testdata", "checksum", "sqlpkg.json")}
-	err := Install(args)
+	args := []string{filepath.Join(m.Dir, "testdata", "checksum", "sqlpkg.json")}
+	err := Install(ctx, m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -110,12 +206,13 @@ func TestInvalidChecksum(t *testing.T) {
 }
 
 func TestUnsupportedPlatform(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
 
-	args := []string{filepath.Join(cmd.WorkDir, "testdata", "unsupported", "sqlpkg.json")}
-	err := Install(args)
+	args := []string{filepath.Join(m.Dir, "testdata", "unsupported", "sqlpkg.json")}
+	err := Install(ctx, m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -124,13 +221,33 @@ func TestUnsupportedPlatform(t *testing.T) {
 	}
 }
 
+func TestPlatformMismatch(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
+
+	args := []string{filepath.Join(m.Dir, "testdata", "mismatch", "sqlpkg.json")}
+	err := Install(ctx, m, args)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "incompatible library files: sparc.so") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fileio.Exists(filepath.Join(m.RepoDir(), "nalgeon", "example")) {
+		t.Fatal("package should not be installed")
+	}
+}
+
 func TestUnknown(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	logx.Mock()
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
 
 	args := []string{"sqlite/unknown"}
-	err := Install(args)
+	err := Install(ctx, m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -4,64 +4,43 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
-	"sort"
 	"text/tabwriter"
 
 	"sqlpkg.org/cli/cmd"
 	"sqlpkg.org/cli/lockfile"
-	"sqlpkg.org/cli/logx"
 	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
 const listHelp = "usage: sqlpkg list"
 
 // List prints all installed packages.
-func List(args []string) error {
+func List(m *sqlpkg.Manager, args []string) error {
 	if len(args) != 0 {
 		return errors.New(listHelp)
 	}
 
-	packages, err := gatherPackages()
+	packages, err := m.List()
 	if err != nil {
 		return err
 	}
 
-	lck, err := cmd.ReadLockfile()
+	lck, err := m.ReadLockfile()
 	if err != nil {
 		return err
 	}
 
-	err = addMissingToLockfile(lck, packages)
+	err = addMissingToLockfile(m, lck, packages)
 	if err != nil {
 		return err
 	}
 
-	sortPackages(packages)
-	printPackages(packages)
+	printPackages(m, packages)
 	return nil
 }
 
-// gatherPackages collects installed packages.
-func gatherPackages() ([]*spec.Package, error) {
-	pattern := filepath.Join(cmd.WorkDir, spec.DirName, "*", "*", spec.FileName)
-	paths, _ := filepath.Glob(pattern)
-
-	packages := []*spec.Package{}
-	for _, path := range paths {
-		pkg, err := spec.ReadLocal(path)
-		if err != nil {
-			return nil, fmt.Errorf("invalid package spec: %s", path)
-		}
-		packages = append(packages, pkg)
-	}
-
-	logx.Debug("gathered %d packages", len(packages))
-	return packages, nil
-}
-
 // addMissingToLockfile adds missing packages to the lockfile.
-func addMissingToLockfile(lck *lockfile.Lockfile, packages []*spec.Package) error {
+func addMissingToLockfile(m *sqlpkg.Manager, lck *lockfile.Lockfile, packages []*spec.Package) error {
 	count := 0
 	for _, pkg := range packages {
 		if lck.Has(pkg.FullName()) {
@@ -75,27 +54,20 @@ func addMissingToLockfile(lck *lockfile.Lockfile, packages []*spec.Package) erro
 		return nil
 	}
 
-	err := lck.Save(cmd.WorkDir)
+	err := lck.Save(m.Dir)
 	if err != nil {
 		return fmt.Errorf("failed to save lockfile: %w", err)
 	}
 
-	logx.Debug("added %d packages to the lockfile", count)
+	m.Logger.Debug("added %d packages to the lockfile", count)
 	return nil
 }
 
-// sortPackages sorts packages by full name.
-func sortPackages(packages []*spec.Package) {
-	sort.Slice(packages, func(i, j int) bool {
-		return packages[i].FullName() < packages[j].FullName()
-	})
-}
-
 // printPackages prints packages.
-func printPackages(packages []*spec.Package) {
-	cmd.PrintScope()
+func printPackages(m *sqlpkg.Manager, packages []*spec.Package) {
+	cmd.PrintScope(m)
 	if len(packages) == 0 {
-		logx.Log("no packages installed")
+		m.Logger.Log("no packages installed")
 		return
 	}
 
@@ -3,19 +3,19 @@ package list
 import (
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/lockfile"
 	"sqlpkg.org/cli/logx"
 )
 
 func TestList(t *testing.T) {
-	_, lockPath := cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
-	mem := logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
 
 	args := []string{}
-	err := List(args)
+	err := List(m, args)
 	if err != nil {
 		t.Fatalf("list error: %v", err)
 	}
@@ -24,7 +24,7 @@ func TestList(t *testing.T) {
 	mem.MustHave(t, "gathered 2 packages")
 	mem.MustHave(t, "added 2 packages to the lockfile")
 
-	lck, err := lockfile.ReadLocal(lockPath)
+	lck, err := lockfile.ReadLocal(m.LockfilePath())
 	if err != nil {
 		t.Fatal("failed to read lockfile")
 	}
@@ -0,0 +1,114 @@
+package loader
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"path/filepath"
+
+	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const loaderHelp = "usage: sqlpkg loader [--output=path] [--include=pkg,...] [--exclude=pkg,...] [--auto | --no-auto]"
+
+// options are the loader command options.
+type options struct {
+	output  string
+	include cmd.ListFlag
+	exclude cmd.ListFlag
+	auto    bool
+	noAuto  bool
+}
+
+// Loader writes the sqlite3 init script that loads installed extensions.
+// With --auto, saves the settings to the config and regenerates the script
+// after each install, update and uninstall. --no-auto turns this off.
+func Loader(m *sqlpkg.Manager, args []string) error {
+	opts, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	cmd.PrintScope(m)
+
+	cfg, err := m.ReadConfig()
+	if err != nil {
+		return err
+	}
+	ldr := &config.Loader{}
+	if cfg.Loader != nil {
+		*ldr = *cfg.Loader
+	}
+	err = applyOptions(ldr, opts)
+	if err != nil {
+		return err
+	}
+
+	count, err := m.WriteLoader(ldr)
+	if err != nil {
+		return err
+	}
+	m.Logger.Log("✓ wrote %d extensions to %s", count, m.LoaderPath(ldr))
+
+	if !ldr.Auto && !opts.noAuto {
+		return nil
+	}
+	cfg.Loader = ldr
+	err = m.SaveConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if opts.auto {
+		m.Logger.Log("✓ enabled automatic init script updates")
+	}
+	if opts.noAuto {
+		m.Logger.Log("✓ disabled automatic init script updates")
+	}
+	return nil
+}
+
+// parseArgs parses command options.
+func parseArgs(args []string) (*options, error) {
+	flags := flag.NewFlagSet("loader", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	opts := &options{}
+	flags.StringVar(&opts.output, "output", "", "path to the init script")
+	flags.Var(&opts.include, "include", "packages to load")
+	flags.Var(&opts.exclude, "exclude", "packages to skip")
+	flags.BoolVar(&opts.auto, "auto", false, "regenerate the script after install, update and uninstall")
+	flags.BoolVar(&opts.noAuto, "no-auto", false, "stop regenerating the script automatically")
+	err := flags.Parse(args)
+	if err != nil || flags.NArg() != 0 {
+		return nil, errors.New(loaderHelp)
+	}
+	if opts.auto && opts.noAuto {
+		return nil, errors.New(loaderHelp)
+	}
+	return opts, nil
+}
+
+// applyOptions overrides the loader settings with the command options.
+func applyOptions(ldr *config.Loader, opts *options) error {
+	if opts.output != "" {
+		output, err := filepath.Abs(opts.output)
+		if err != nil {
+			return err
+		}
+		ldr.Output = output
+	}
+	if len(opts.include) != 0 {
+		ldr.Include = opts.include
+	}
+	if len(opts.exclude) != 0 {
+		ldr.Exclude = opts.exclude
+	}
+	if opts.auto {
+		ldr.Auto = true
+	}
+	if opts.noAuto {
+		ldr.Auto = false
+	}
+	return nil
+}
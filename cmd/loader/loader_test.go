@@ -0,0 +1,152 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+func TestLoader(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("default", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Loader(m, nil)
+		if err != nil {
+			t.Fatalf("loader error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "✓ wrote 2 extensions to .sqlpkg/init.sql")
+
+		script := readScript(t, filepath.Join(spec.DirName, sqlpkg.LoaderFileName))
+		example := strings.Index(script, "nalgeon/example/example")
+		stmt := strings.Index(script, "sqlite/stmt/stmtvtab")
+		if example == -1 || stmt == -1 || example > stmt {
+			t.Fatalf("unexpected script: %s", script)
+		}
+		if !strings.Contains(script, " sqlite3_example_init\n") {
+			t.Errorf("missing entry point: %s", script)
+		}
+		if strings.Contains(script, "nalgeon/broken") {
+			t.Errorf("unexpected package: %s", script)
+		}
+		if fileio.Exists(filepath.Join(spec.DirName, "config.json")) {
+			t.Error("config should not be saved")
+		}
+	})
+	t.Run("include", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "include.sql")
+		mem := logx.Mock(m.Logger)
+		err := Loader(m, []string{"--include=sqlite/*", "--output=" + output})
+		if err != nil {
+			t.Fatalf("loader error: %v", err)
+		}
+		mem.MustHave(t, "✓ wrote 1 extensions to")
+		script := readScript(t, output)
+		if !strings.Contains(script, "sqlite/stmt/stmtvtab") || strings.Contains(script, "nalgeon/example") {
+			t.Errorf("unexpected script: %s", script)
+		}
+	})
+	t.Run("exclude", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "exclude.sql")
+		mem := logx.Mock(m.Logger)
+		err := Loader(m, []string{"--exclude=sqlite/stmt", "--output=" + output})
+		if err != nil {
+			t.Fatalf("loader error: %v", err)
+		}
+		mem.MustHave(t, "✓ wrote 1 extensions to")
+		script := readScript(t, output)
+		if !strings.Contains(script, "nalgeon/example/example") || strings.Contains(script, "sqlite/stmt") {
+			t.Errorf("unexpected script: %s", script)
+		}
+	})
+	t.Run("help", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Loader(m, []string{"--auto", "--no-auto"})
+		if err == nil || err.Error() != loaderHelp {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = Loader(m, []string{"nalgeon/example"})
+		if err == nil || err.Error() != loaderHelp {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAuto(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	scriptPath := filepath.Join(spec.DirName, sqlpkg.LoaderFileName)
+
+	t.Run("enable", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Loader(m, []string{"--auto", "--exclude=nalgeon/*"})
+		if err != nil {
+			t.Fatalf("loader error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "✓ enabled automatic init script updates")
+
+		cfg, err := m.ReadConfig()
+		if err != nil {
+			t.Fatalf("failed to read config: %v", err)
+		}
+		if cfg.Loader == nil || !cfg.Loader.Auto {
+			t.Fatalf("auto updates are not enabled: %+v", cfg.Loader)
+		}
+		if len(cfg.Loader.Exclude) != 1 || cfg.Loader.Exclude[0] != "nalgeon/*" {
+			t.Errorf("unexpected exclude: %v", cfg.Loader.Exclude)
+		}
+	})
+	t.Run("regenerate", func(t *testing.T) {
+		err := os.RemoveAll(spec.Dir(m.Dir, "sqlite", "stmt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mem := logx.Mock(m.Logger)
+		err = m.RegenerateLoader()
+		if err != nil {
+			t.Fatalf("regenerate error: %v", err)
+		}
+		mem.MustHave(t, "✓ updated init script")
+		script := readScript(t, scriptPath)
+		if strings.Contains(script, ".load") {
+			t.Errorf("unexpected script: %s", script)
+		}
+	})
+	t.Run("disable", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Loader(m, []string{"--no-auto"})
+		if err != nil {
+			t.Fatalf("loader error: %v", err)
+		}
+		mem.MustHave(t, "✓ disabled automatic init script updates")
+
+		os.Remove(scriptPath)
+		err = m.RegenerateLoader()
+		if err != nil {
+			t.Fatalf("regenerate error: %v", err)
+		}
+		if fileio.Exists(scriptPath) {
+			t.Error("init script should not be regenerated")
+		}
+	})
+}
+
+func readScript(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read init script: %v", err)
+	}
+	return string(data)
+}
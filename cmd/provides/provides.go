@@ -0,0 +1,44 @@
+package provides
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const providesHelp = "usage: sqlpkg provides <symbol>"
+
+// Provides prints packages that define the SQL symbol,
+// both installed and available in registries.
+func Provides(ctx context.Context, m *sqlpkg.Manager, args []string) error {
+	if len(args) != 1 {
+		return errors.New(providesHelp)
+	}
+
+	symbol := args[0]
+	results, err := m.Provides(ctx, symbol)
+	if err != nil {
+		return err
+	}
+
+	if len(results) == 0 {
+		m.Logger.Log("no packages provide %s", symbol)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(m.Logger.Output(), 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	for _, res := range results {
+		status := "✘ not installed"
+		if res.Installed {
+			status = "✓ installed"
+		}
+		pkg := res.Package
+		fmt.Fprintf(w, "%s\t%s\t%s\n", pkg.FullName(), pkg.Version, status)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package provides
+
+import (
+	"context"
+	"testing"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestProvides(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	cfg := &config.Config{
+		Registries: []spec.Registry{
+			{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Index: "https://example.org/index.json"},
+		},
+	}
+	err := m.SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig: unexpected error %v", err)
+	}
+
+	t.Run("installed", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Provides(ctx, m, []string{"example_fn"})
+		if err != nil {
+			t.Fatalf("provides error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "nalgeon/example")
+		mem.MustHave(t, "✓ installed")
+	})
+	t.Run("registry", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Provides(ctx, m, []string{"percentile"})
+		if err != nil {
+			t.Fatalf("provides error: %v", err)
+		}
+		mem.MustHave(t, "nalgeon/stats")
+		mem.MustHave(t, "✘ not installed")
+	})
+	t.Run("not found", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Provides(ctx, m, []string{"regexp_like"})
+		if err != nil {
+			t.Fatalf("provides error: %v", err)
+		}
+		mem.MustHave(t, "no packages provide regexp_like")
+	})
+}
+
+func TestHelp(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+
+	err := Provides(ctx, m, nil)
+	if err == nil || err.Error() != providesHelp {
+		t.Fatalf("expected help error, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const searchHelp = "usage: sqlpkg search [--keyword=kw] [--owner=name] [--json] [terms...]"
+
+// options are the search command options.
+type options struct {
+	query sqlpkg.SearchQuery
+	// json prints the results as JSON instead.
+	json bool
+}
+
+// result describes a found package.
+type result struct {
+	Owner       string `json:"owner"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+	Registry    string `json:"registry,omitempty"`
+}
+
+// Search finds packages in the registry indexes.
+func Search(ctx context.Context, m *sqlpkg.Manager, args []string) error {
+	opts, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	found, err := m.Search(ctx, opts.query)
+	if err != nil {
+		return err
+	}
+
+	results := make([]result, len(found))
+	for i, res := range found {
+		pkg := res.Package
+		results[i] = result{
+			Owner:       pkg.Owner,
+			Name:        pkg.Name,
+			Version:     pkg.Version,
+			Description: pkg.Description,
+			Registry:    pkg.Registry,
+		}
+	}
+
+	if opts.json {
+		data, err := json.MarshalIndent(results, "", "    ")
+		if err != nil {
+			return err
+		}
+		m.Logger.Log(string(data))
+		return nil
+	}
+
+	printResults(m, results)
+	return nil
+}
+
+// parseArgs parses command options.
+func parseArgs(args []string) (*options, error) {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	opts := &options{}
+	flags.StringVar(&opts.query.Keyword, "keyword", "", "package keyword")
+	flags.StringVar(&opts.query.Owner, "owner", "", "package owner")
+	flags.BoolVar(&opts.json, "json", false, "print as JSON")
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, errors.New(searchHelp)
+	}
+	opts.query.Terms = flags.Args()
+	if len(opts.query.Terms) == 0 && opts.query.Keyword == "" && opts.query.Owner == "" {
+		return nil, errors.New(searchHelp)
+	}
+	return opts, nil
+}
+
+// printResults prints found packages.
+func printResults(m *sqlpkg.Manager, results []result) {
+	if len(results) == 0 {
+		m.Logger.Log("no packages found")
+		return
+	}
+
+	w := tabwriter.NewWriter(m.Logger.Output(), 0, 4, 2, ' ', 0)
+	defer w.Flush()
+
+	for _, res := range results {
+		fmt.Fprintf(w, "%s/%s\t%s\t%s\n", res.Owner, res.Name, res.Version, res.Description)
+	}
+}
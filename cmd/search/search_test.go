@@ -0,0 +1,98 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+func setupRegistry(t *testing.T) *sqlpkg.Manager {
+	m := sqlpkgtest.SetupRepo(t)
+	cfg := &config.Config{
+		Registries: []spec.Registry{
+			{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Index: "https://example.org/index.json"},
+		},
+	}
+	err := m.SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig: unexpected error %v", err)
+	}
+	return m
+}
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	m := setupRegistry(t)
+	defer sqlpkgtest.TeardownRepo(t)
+
+	t.Run("terms", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Search(ctx, m, []string{"median"})
+		if err != nil {
+			t.Fatalf("search error: %v", err)
+		}
+		mem.Print()
+		mem.MustHave(t, "nalgeon/stats")
+		mem.MustHave(t, "0.2.0")
+		mem.MustHave(t, "Statistics functions.")
+		mem.MustHave(t, "asg017/vec")
+		mem.MustNotHave(t, "nalgeon/math")
+	})
+	t.Run("filters", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Search(ctx, m, []string{"--keyword=math", "--owner=nalgeon"})
+		if err != nil {
+			t.Fatalf("search error: %v", err)
+		}
+		mem.MustHave(t, "nalgeon/stats")
+		mem.MustHave(t, "nalgeon/math")
+		mem.MustNotHave(t, "asg017/vec")
+	})
+	t.Run("not found", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Search(ctx, m, []string{"crypto"})
+		if err != nil {
+			t.Fatalf("search error: %v", err)
+		}
+		mem.MustHave(t, "no packages found")
+	})
+	t.Run("json", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Search(ctx, m, []string{"--json", "stats"})
+		if err != nil {
+			t.Fatalf("search error: %v", err)
+		}
+		var results []result
+		err = json.Unmarshal([]byte(mem.Lines[len(mem.Lines)-1]), &results)
+		if err != nil {
+			t.Fatalf("invalid json: %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("expected 1 result, got %d", len(results))
+		}
+		want := result{
+			Owner: "nalgeon", Name: "stats", Version: "0.2.0",
+			Description: "Statistics functions.", Registry: "internal",
+		}
+		if results[0] != want {
+			t.Errorf("unexpected result %+v", results[0])
+		}
+	})
+}
+
+func TestHelp(t *testing.T) {
+	ctx := context.Background()
+	m := setupRegistry(t)
+	defer sqlpkgtest.TeardownRepo(t)
+
+	err := Search(ctx, m, nil)
+	if err == nil || err.Error() != searchHelp {
+		t.Fatalf("expected help error, got %v", err)
+	}
+}
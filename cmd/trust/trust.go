@@ -0,0 +1,128 @@
+package trust
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/minisign"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const help = "usage: sqlpkg trust list | add <owner> <key> | remove <owner> [key]"
+
+// Trust manages public keys trusted to sign packages.
+func Trust(m *sqlpkg.Manager, args []string) error {
+	if len(args) == 0 {
+		return errors.New(help)
+	}
+
+	switch args[0] {
+	case "list":
+		return list(m, args[1:])
+	case "add":
+		return add(m, args[1:])
+	case "remove":
+		return remove(m, args[1:])
+	default:
+		return errors.New(help)
+	}
+}
+
+// list prints trusted keys.
+func list(m *sqlpkg.Manager, args []string) error {
+	if len(args) != 0 {
+		return errors.New(help)
+	}
+
+	cmd.PrintScope(m)
+	store, err := m.ReadTrustStore()
+	if err != nil {
+		return err
+	}
+
+	owners := store.Owners()
+	if len(owners) == 0 {
+		m.Logger.Log("no trusted keys")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 0, ' ', 0)
+	defer w.Flush()
+
+	for _, owner := range owners {
+		for _, key := range store.Get(owner) {
+			fmt.Fprintln(w, owner, "\t", key)
+		}
+	}
+	return nil
+}
+
+// add adds a trusted key for the package owner.
+func add(m *sqlpkg.Manager, args []string) error {
+	if len(args) != 2 {
+		return errors.New(help)
+	}
+
+	cmd.PrintScope(m)
+	owner := args[0]
+	key, err := minisign.ParsePublicKey(args[1])
+	if err != nil {
+		return err
+	}
+
+	store, err := m.ReadTrustStore()
+	if err != nil {
+		return err
+	}
+
+	if !store.Add(owner, key.String()) {
+		m.Logger.Log("✓ key %s is already trusted for %s", key.ID(), owner)
+		return nil
+	}
+
+	err = m.SaveTrustStore(store)
+	if err != nil {
+		return err
+	}
+
+	m.Logger.Log("✓ trusted key %s for %s", key.ID(), owner)
+	return nil
+}
+
+// remove removes a trusted key (or all keys) for the package owner.
+func remove(m *sqlpkg.Manager, args []string) error {
+	if len(args) != 1 && len(args) != 2 {
+		return errors.New(help)
+	}
+
+	cmd.PrintScope(m)
+	owner := args[0]
+	keyStr := ""
+	if len(args) == 2 {
+		key, err := minisign.ParsePublicKey(args[1])
+		if err != nil {
+			return err
+		}
+		keyStr = key.String()
+	}
+
+	store, err := m.ReadTrustStore()
+	if err != nil {
+		return err
+	}
+
+	if !store.Remove(owner, keyStr) {
+		return errors.New("key is not trusted")
+	}
+
+	err = m.SaveTrustStore(store)
+	if err != nil {
+		return err
+	}
+
+	m.Logger.Log("✓ removed trusted keys for %s", owner)
+	return nil
+}
@@ -0,0 +1,98 @@
+package trust
+
+import (
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+)
+
+const testPublicKey = "RWQBAgMEBQYHCNbfPh0yyFgOzPEFJwh6VAoknPwKGurCvJmBBXJrKg7g"
+
+func TestAdd(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+
+	t.Run("add", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Trust(m, []string{"add", "nalgeon", testPublicKey})
+		if err != nil {
+			t.Fatalf("trust error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "trusted key 0807060504030201 for nalgeon")
+
+		store, err := m.ReadTrustStore()
+		if err != nil {
+			t.Fatalf("ReadTrustStore: %v", err)
+		}
+		if len(store.Get("nalgeon")) != 1 {
+			t.Fatalf("unexpected keys: %v", store.Get("nalgeon"))
+		}
+	})
+	t.Run("already trusted", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Trust(m, []string{"add", "nalgeon", testPublicKey})
+		if err != nil {
+			t.Fatalf("trust error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "already trusted")
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Trust(m, []string{"add", "nalgeon", "invalid"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "invalid public key") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestRemove(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
+
+	err := Trust(m, []string{"add", "nalgeon", testPublicKey})
+	if err != nil {
+		t.Fatalf("trust error: %v", err)
+	}
+
+	t.Run("remove", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		err := Trust(m, []string{"remove", "nalgeon", testPublicKey})
+		if err != nil {
+			t.Fatalf("trust error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "removed trusted keys for nalgeon")
+
+		store, _ := m.ReadTrustStore()
+		if len(store.Get("nalgeon")) != 0 {
+			t.Fatalf("unexpected keys: %v", store.Get("nalgeon"))
+		}
+	})
+	t.Run("not trusted", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		err := Trust(m, []string{"remove", "nalgeon"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestHelp(t *testing.T) {
+	m := sqlpkgtest.Manager()
+	logx.Mock(m.Logger)
+	err := Trust(m, []string{"unknown"})
+	if err == nil || !strings.HasPrefix(err.Error(), "usage") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
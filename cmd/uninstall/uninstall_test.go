@@ -5,37 +5,37 @@ import (
 	"strings"
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
 	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/lockfile"
 	"sqlpkg.org/cli/logx"
 )
 
 func TestUninstall(t *testing.T) {
-	repoDir, lockPath := cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
 
-	memory := logx.Mock()
+	memory := logx.Mock(m.Logger)
 
 	args := []string{"nalgeon/example"}
-	err := Uninstall(args)
+	err := Uninstall(m, args)
 	if err != nil {
 		t.Fatalf("uninstallation error: %v", err)
 	}
 
 	validateLog(t, memory)
-	validatePackage(t, repoDir, lockPath, "nalgeon", "example")
+	validatePackage(t, m.RepoDir(), m.LockfilePath(), "nalgeon", "example")
 }
 
 func TestUnknown(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
-	logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	logx.Mock(m.Logger)
 
 	args := []string{"sqlite/unknown"}
-	err := Uninstall(args)
+	err := Uninstall(m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
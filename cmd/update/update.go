@@ -1,27 +1,38 @@
 package update
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"path/filepath"
 
 	"sqlpkg.org/cli/cmd"
-	"sqlpkg.org/cli/lockfile"
-	"sqlpkg.org/cli/logx"
 	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
-const updateHelp = "usage: sqlpkg update [package]"
+const updateHelp = "usage: sqlpkg update [--require-checksums] [package]"
 
-// UpdateAll updates installed packages to latest versions.
-func UpdateAll(args []string) error {
-	if len(args) != 0 {
-		return errors.New(updateHelp)
+// parseArgs parses command options and returns the remaining arguments.
+func parseArgs(args []string) (sqlpkg.InstallOptions, []string, error) {
+	flags := flag.NewFlagSet("update", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	requireChecksums := flags.Bool("require-checksums", false, "fail if an asset has no verifiable checksum")
+	err := flags.Parse(args)
+	if err != nil {
+		return sqlpkg.InstallOptions{}, nil, errors.New(updateHelp)
 	}
+	opts := sqlpkg.InstallOptions{RequireChecksums: *requireChecksums}
+	return opts, flags.Args(), nil
+}
 
-	cmd.PrintScope()
+// updateAll updates installed packages to latest versions.
+func updateAll(ctx context.Context, m *sqlpkg.Manager, opts sqlpkg.InstallOptions) error {
+	cmd.PrintScope(m)
 
-	pattern := filepath.Join(cmd.WorkDir, spec.DirName, "*", "*", spec.FileName)
+	pattern := filepath.Join(m.RepoDir(), "*", "*", spec.FileName)
 	paths, _ := filepath.Glob(pattern)
 
 	if len(paths) == 0 {
@@ -29,151 +40,71 @@ func UpdateAll(args []string) error {
 		return nil
 	}
 
-	lck, err := cmd.ReadLockfile()
-	if err != nil {
-		return err
-	}
-
 	count := 0
 	for _, path := range paths {
+		if ctx.Err() != nil {
+			// interrupted, don't bother with the rest
+			return ctx.Err()
+		}
 		pkg, err := spec.ReadLocal(path)
 		if err != nil {
-			logx.Log("! invalid package %s: %s", path, err)
+			m.Logger.Log("! invalid package %s: %s", path, err)
 			continue
 		}
-		logx.Debug("found local spec from %s", path)
-		logx.Debug("read package %s, version = %s", pkg.FullName(), pkg.Version)
 
-		logx.Log("> updating %s...", pkg.FullName())
-		updPkg, err := updatePackage(lck, getSpecPath(pkg))
+		m.Logger.Log("> updating %s...", pkg.FullName())
+		res, err := m.Update(ctx, pkg.FullName(), opts)
 		if err != nil {
-			logx.Log("! error updating %s: %s", pkg.FullName(), err)
+			m.Logger.Log("! error updating %s: %s", pkg.FullName(), err)
 			continue
 		}
-		if updPkg == nil {
-			logx.Log("✓ already at the latest version")
+		if !res.Updated {
+			m.Logger.Log("✓ already at the latest version")
 			continue
 		}
-		updVersion := updPkg.Version
+		updVersion := res.Package.Version
 		if updVersion == "" {
 			updVersion = "latest version"
 		}
-		logx.Log("✓ updated package %s to %s", updPkg.FullName(), updVersion)
+		m.Logger.Log("✓ updated package %s to %s", res.Package.FullName(), updVersion)
 		count += 1
 	}
 
-	logx.Log("updated %d packages", count)
-	return nil
+	m.Logger.Log("updated %d packages", count)
+	if count == 0 {
+		return nil
+	}
+	return m.RegenerateLoader()
 }
 
 // Update updates a specific package to the latest version.
-func Update(args []string) error {
-	if len(args) != 1 {
-		return errors.New(updateHelp)
-	}
-
-	cmd.PrintScope()
-
-	fullName := args[0]
-	path, err := cmd.GetPathByFullName(fullName)
+// Updates all installed packages if the package is not specified.
+func Update(ctx context.Context, m *sqlpkg.Manager, args []string) error {
+	opts, args, err := parseArgs(args)
 	if err != nil {
 		return err
 	}
-
-	pkg, err := spec.ReadLocal(path)
-	if err != nil {
-		return fmt.Errorf("invalid package: %w", err)
+	if len(args) > 1 {
+		return errors.New(updateHelp)
 	}
-	logx.Debug("found local spec from %s", path)
-	logx.Debug("read package %s, version = %s", pkg.FullName(), pkg.Version)
-
-	lck, err := cmd.ReadLockfile()
-	if err != nil {
-		return err
+	if len(args) == 0 {
+		return updateAll(ctx, m, opts)
 	}
 
-	logx.Log("> updating %s...", pkg.FullName())
-	updPkg, err := updatePackage(lck, getSpecPath(pkg))
+	cmd.PrintScope(m)
+
+	fullName := args[0]
+	m.Logger.Log("> updating %s...", fullName)
+	res, err := m.Update(ctx, fullName, opts)
 	if err != nil {
 		return fmt.Errorf("failed to update: %w", err)
 	}
 
-	if updPkg == nil {
-		logx.Log("✓ already at the latest version")
+	if !res.Updated {
+		m.Logger.Log("✓ already at the latest version")
 		return nil
 	}
 
-	logx.Log("✓ updated package %s to %s", updPkg.FullName(), updPkg.Version)
-	return nil
-}
-
-// updatePackage updates a package.
-// Returns true if the package was actually updated, false otherwise
-// (already at the latest version or encountered an error).
-func updatePackage(lck *lockfile.Lockfile, path string) (*spec.Package, error) {
-	logx.Debug("using spec path: %s", path)
-	pkg, err := cmd.ReadSpec(path)
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd.ResolveVersion(pkg)
-	if err != nil {
-		return nil, err
-	}
-
-	if !cmd.HasNewVersion(pkg) {
-		return nil, nil
-	}
-
-	err = cmd.ReadChecksums(pkg)
-	if err != nil {
-		return nil, err
-	}
-
-	assetUrl, err := cmd.BuildAssetPath(pkg)
-	if err != nil {
-		return nil, err
-	}
-
-	asset, err := cmd.DownloadAsset(pkg, assetUrl)
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd.ValidateAsset(pkg, asset)
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd.UnpackAsset(pkg, asset)
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd.InstallFiles(pkg, asset)
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd.DequarantineFiles(pkg)
-	if err != nil {
-		return nil, err
-	}
-
-	err = cmd.AddToLockfile(lck, pkg)
-	if err != nil {
-		return nil, err
-	}
-
-	return pkg, nil
-}
-
-// getSpecPath returns a remote package spec path.
-func getSpecPath(pkg *spec.Package) string {
-	if pkg.Specfile != "" {
-		return pkg.Specfile
-	}
-	// in older specs the .Specfile may be empty
-	return pkg.FullName()
+	m.Logger.Log("✓ updated package %s to %s", res.Package.FullName(), res.Package.Version)
+	return m.RegenerateLoader()
 }
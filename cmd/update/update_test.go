@@ -1,61 +1,65 @@
 package update
 
 import (
+	"context"
 	"path/filepath"
 	"strings"
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
 	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/lockfile"
 	"sqlpkg.org/cli/logx"
 	"sqlpkg.org/cli/spec"
 )
 
 func TestUpdate(t *testing.T) {
-	repoDir, lockPath := cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "success")
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "success")
 
-	mem := logx.Mock()
+	mem := logx.Mock(m.Logger)
 
 	args := []string{"nalgeon/example"}
-	err := Update(args)
+	err := Update(ctx, m, args)
 	if err != nil {
 		t.Fatalf("update error: %v", err)
 	}
 
 	validateLog(t, mem)
-	validatePackage(t, repoDir, lockPath, "nalgeon", "example")
+	validatePackage(t, m.RepoDir(), m.LockfilePath(), "nalgeon", "example")
 }
 
 func TestUpdateAll(t *testing.T) {
-	repoDir, lockPath := cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "success")
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "success")
 
-	mem := logx.Mock()
+	mem := logx.Mock(m.Logger)
 
 	args := []string{}
-	err := UpdateAll(args)
+	err := Update(ctx, m, args)
 	if err != nil {
 		t.Fatalf("update error: %v", err)
 	}
 
 	validateLog(t, mem)
 	mem.MustHave(t, "updated 1 packages")
-	validatePackage(t, repoDir, lockPath, "nalgeon", "example")
+	validatePackage(t, m.RepoDir(), m.LockfilePath(), "nalgeon", "example")
 }
 
 func TestLatest(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "latest")
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "latest")
 
-	mem := logx.Mock()
+	mem := logx.Mock(m.Logger)
 
 	args := []string{"nalgeon/example"}
-	err := Update(args)
+	err := Update(ctx, m, args)
 	if err != nil {
 		t.Fatalf("update error: %v", err)
 	}
@@ -63,7 +67,7 @@ func TestLatest(t *testing.T) {
 	mem.Print()
 	mem.MustHave(t, "already at the latest version")
 
-	pkg, err := spec.ReadLocal(spec.Path(cmd.WorkDir, "nalgeon", "example"))
+	pkg, err := spec.ReadLocal(spec.Path(m.Dir, "nalgeon", "example"))
 	if err != nil {
 		t.Fatalf("read pkg error: %v", err)
 	}
@@ -73,14 +77,15 @@ func TestLatest(t *testing.T) {
 }
 
 func TestNoVersion(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "version")
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "version")
 
-	mem := logx.Mock()
+	mem := logx.Mock(m.Logger)
 
 	args := []string{"nalgeon/example"}
-	err := Update(args)
+	err := Update(ctx, m, args)
 	if err != nil {
 		t.Fatalf("update error: %v", err)
 	}
@@ -90,13 +95,32 @@ func TestNoVersion(t *testing.T) {
 	mem.MustHave(t, "updated package nalgeon/example")
 }
 
+func TestRequireChecksums(t *testing.T) {
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "version")
+
+	logx.Mock(m.Logger)
+
+	args := []string{"--require-checksums", "nalgeon/example"}
+	err := Update(ctx, m, args)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "checksums are required") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestInvalidChecksum(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "checksum")
+	ctx := context.Background()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "checksum")
 
 	args := []string{"nalgeon/example"}
-	err := Update(args)
+	err := Update(ctx, m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
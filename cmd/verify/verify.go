@@ -0,0 +1,136 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/manifest"
+	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+const verifyHelp = "usage: sqlpkg verify [package]"
+
+// A status is the verification outcome of a package.
+type status int
+
+const (
+	// statusIntact means the package files match the manifest.
+	statusIntact status = iota
+	// statusModified means the package files differ from the manifest.
+	statusModified
+	// statusUnverified means there is no manifest to check the files against.
+	statusUnverified
+)
+
+// Verify checks installed package files against their manifests.
+// Verifies all installed packages if the package is not specified.
+func Verify(m *sqlpkg.Manager, args []string) error {
+	if len(args) > 1 {
+		return errors.New(verifyHelp)
+	}
+
+	cmd.PrintScope(m)
+
+	if len(args) == 0 {
+		return verifyAll(m)
+	}
+
+	fullName := args[0]
+	pkg := m.ReadInstalledSpec(fullName)
+	if pkg == nil {
+		return sqlpkg.ErrNotInstalled
+	}
+
+	st, err := verifyPackage(m, pkg)
+	if err != nil {
+		return err
+	}
+	switch st {
+	case statusModified:
+		return fmt.Errorf("package %s has been modified", pkg.FullName())
+	case statusUnverified:
+		return fmt.Errorf("package %s could not be verified", pkg.FullName())
+	}
+	return nil
+}
+
+// verifyAll checks all installed packages.
+func verifyAll(m *sqlpkg.Manager) error {
+	packages, err := m.List()
+	if err != nil {
+		return err
+	}
+	if len(packages) == 0 {
+		m.Logger.Log("no packages installed")
+		return nil
+	}
+
+	failed, unverified := 0, 0
+	for _, pkg := range packages {
+		st, err := verifyPackage(m, pkg)
+		if err != nil {
+			m.Logger.Log("! error verifying %s: %s", pkg.FullName(), err)
+			failed += 1
+			continue
+		}
+		switch st {
+		case statusModified:
+			failed += 1
+		case statusUnverified:
+			unverified += 1
+		}
+	}
+
+	if failed > 0 && unverified > 0 {
+		return fmt.Errorf("%d of %d packages failed verification, %d could not be verified",
+			failed, len(packages), unverified)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d packages failed verification", failed, len(packages))
+	}
+	if unverified > 0 {
+		return fmt.Errorf("%d of %d packages could not be verified", unverified, len(packages))
+	}
+	m.Logger.Log("verified %d packages", len(packages))
+	return nil
+}
+
+// verifyPackage checks the package files and prints the report.
+// Packages installed without a manifest are reported as unverified.
+// Returns an error (and no status) if the files could not be checked.
+func verifyPackage(m *sqlpkg.Manager, pkg *spec.Package) (status, error) {
+	m.Logger.Log("> verifying %s...", pkg.FullName())
+	report, err := m.VerifyFiles(pkg)
+	if errors.Is(err, sqlpkg.ErrMissingManifest) {
+		m.Logger.Warn("unverified: %s, reinstall the package to create it", err)
+		return statusUnverified, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if report.OK() {
+		m.Logger.Log("✓ package files are intact")
+		return statusIntact, nil
+	}
+
+	printReport(m, report)
+	return statusModified, nil
+}
+
+// printReport prints modified, missing and extra package files.
+func printReport(m *sqlpkg.Manager, report *manifest.Report) {
+	m.Logger.Log("! %d modified, %d missing, %d extra files",
+		len(report.Modified), len(report.Missing), len(report.Extra))
+	for _, path := range report.Modified {
+		m.Logger.Log("  modified: %s", path)
+	}
+	for _, path := range report.Missing {
+		m.Logger.Log("  missing:  %s", path)
+	}
+	for _, path := range report.Extra {
+		m.Logger.Log("  extra:    %s", path)
+	}
+}
@@ -0,0 +1,101 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/internal/sqlpkgtest"
+	"sqlpkg.org/cli/logx"
+)
+
+func TestVerify(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("intact", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"nalgeon/example"}
+		err := Verify(m, args)
+		if err != nil {
+			t.Fatalf("verify error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "> verifying nalgeon/example...")
+		mem.MustHave(t, "✓ package files are intact")
+	})
+	t.Run("modified", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"nalgeon/tampered"}
+		err := Verify(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "package nalgeon/tampered has been modified") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "! 1 modified, 1 missing, 1 extra files")
+		mem.MustHave(t, "modified: dist/tampered.so")
+		mem.MustHave(t, "missing:  README.md")
+		mem.MustHave(t, "extra:    extra.so")
+	})
+	t.Run("missing manifest", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"sqlite/legacy"}
+		err := Verify(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "package sqlite/legacy could not be verified") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "! unverified: package manifest is missing")
+	})
+	t.Run("not installed", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		args := []string{"sqlite/unknown"}
+		err := Verify(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "package is not installed") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestVerifyAll(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
+
+	args := []string{}
+	err := Verify(m, args)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "1 of 3 packages failed verification, 1 could not be verified") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, "> verifying nalgeon/example...")
+	mem.MustHave(t, "> verifying nalgeon/tampered...")
+	mem.MustHave(t, "> verifying sqlite/legacy...")
+}
+
+func TestHelp(t *testing.T) {
+	m := sqlpkgtest.Manager()
+	logx.Mock(m.Logger)
+	args := []string{"nalgeon/example", "sqlite/stmt"}
+	err := Verify(m, args)
+	if err == nil || err.Error() != verifyHelp {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -1,99 +1,196 @@
 package which
 
 import (
+	"encoding/json"
 	"errors"
-	"path/filepath"
-	"runtime"
+	"flag"
+	"fmt"
+	"io"
 	"strings"
 
-	"sqlpkg.org/cli/cmd"
-	"sqlpkg.org/cli/fileio"
-	"sqlpkg.org/cli/logx"
 	"sqlpkg.org/cli/spec"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
-const help = "usage: sqlpkg which <package>"
+const help = "usage: sqlpkg which [--verify] [--entrypoints] [--format=path|sql|dot|json] [--all | <package>]"
 
-// maps the OS name to the file extension
-var fileExt = map[string]string{
-	"darwin":  ".dylib",
-	"linux":   ".so",
-	"windows": ".dll",
+// output formats
+const (
+	formatPath = "path"
+	formatSQL  = "sql"
+	formatDot  = "dot"
+	formatJSON = "json"
+)
+
+// options are the which command options.
+type options struct {
+	// verify checks the package files against the manifest first.
+	verify bool
+	// entrypoints prints extension entry points instead of the path.
+	entrypoints bool
+	// all prints extension files of all installed packages.
+	all bool
+	// format is the output format.
+	format string
 }
 
 // Which prints a path to the extension file.
-func Which(args []string) error {
+func Which(m *sqlpkg.Manager, args []string) error {
+	opts, args, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	if opts.all {
+		if len(args) != 0 {
+			return errors.New(help)
+		}
+		return whichAll(m, opts)
+	}
 	if len(args) != 1 {
 		return errors.New(help)
 	}
 
-	parts := strings.Split(args[0], "/")
-	if len(parts) != 2 {
-		return errors.New("invalid package name")
+	res, err := m.Which(args[0])
+	if err != nil {
+		return err
 	}
 
-	owner, name := parts[0], parts[1]
-	pkgDir := spec.Dir(cmd.WorkDir, owner, name)
-	if !fileio.Exists(pkgDir) {
-		return errors.New("package is not installed")
+	pkg := res.Package
+	if opts.verify {
+		err := verifyFiles(m, pkg)
+		if err != nil {
+			return err
+		}
 	}
 
-	path := findExact(pkgDir, name, runtime.GOOS)
-	if path != "" {
-		logx.Log(path)
-		return nil
+	if opts.format != formatPath {
+		return printExtensions(m, res.Extensions, opts.format)
 	}
 
-	paths := findByExt(pkgDir, name, runtime.GOOS)
-	if len(paths) == 0 {
-		return errors.New("extension file is not found")
+	if res.Exact {
+		path := res.Extensions[0].Path
+		if opts.entrypoints {
+			return printEntrypoints(m, pkg, path)
+		}
+		m.Logger.Log(path)
+		return nil
 	}
 
-	logx.Log("exact match not found")
-	logx.Log("possible matches:")
-	for _, path := range paths {
-		logx.Log(path)
+	m.Logger.Log("exact match not found")
+	m.Logger.Log("possible matches:")
+	for _, ext := range res.Extensions {
+		if opts.entrypoints {
+			entries, _ := m.Entrypoints(pkg, ext.Path)
+			m.Logger.Log("%s: %s", ext.Path, strings.Join(entries, ", "))
+			continue
+		}
+		m.Logger.Log(ext.Path)
 	}
 
 	return nil
 }
 
-// findExact returns a path to the extension file
-// if the extension file has the same name as the package itself.
-func findExact(pkgDir, name, os string) string {
-	{
-		// e.g., text.dylib
-		pattern := filepath.Join(pkgDir, name+fileExt[os])
-		paths, _ := filepath.Glob(pattern)
-		if len(paths) != 0 {
-			return paths[0]
-		}
+// parseArgs parses command options and returns the remaining arguments.
+func parseArgs(args []string) (*options, []string, error) {
+	flags := flag.NewFlagSet("which", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	opts := &options{}
+	flags.BoolVar(&opts.verify, "verify", false, "verify package files before printing the path")
+	flags.BoolVar(&opts.entrypoints, "entrypoints", false, "print extension entry points")
+	flags.BoolVar(&opts.all, "all", false, "print extension files of all installed packages")
+	flags.StringVar(&opts.format, "format", formatPath, "output format")
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, nil, errors.New(help)
+	}
+
+	switch opts.format {
+	case formatPath, formatSQL, formatDot, formatJSON:
+	default:
+		return nil, nil, fmt.Errorf("unknown format: %s", opts.format)
+	}
+	if opts.entrypoints && (opts.all || opts.format != formatPath) {
+		// other formats already include entry points
+		return nil, nil, errors.New(help)
 	}
-	{
-		// e.g., text0.dylib
-		pattern := filepath.Join(pkgDir, name+"[0-9]"+fileExt[os])
-		paths, _ := filepath.Glob(pattern)
-		if len(paths) != 0 {
-			return paths[0]
+	return opts, flags.Args(), nil
+}
+
+// whichAll prints extension files of all installed packages.
+// Skips packages without extension files for the current OS.
+func whichAll(m *sqlpkg.Manager, opts *options) error {
+	packages, err := m.List()
+	if err != nil {
+		return err
+	}
+
+	exts := []sqlpkg.Extension{}
+	for _, pkg := range packages {
+		if opts.verify {
+			err := verifyFiles(m, pkg)
+			if err != nil {
+				return fmt.Errorf("%s: %w", pkg.FullName(), err)
+			}
 		}
+		found, err := m.FindExtensions(pkg)
+		if err != nil {
+			m.Logger.Debug("skipping %s: %s", pkg.FullName(), err)
+			continue
+		}
+		exts = append(exts, found...)
 	}
-	{
-		// e.g., libtext.dylib
-		pattern := filepath.Join(pkgDir, "lib"+name+fileExt[os])
-		paths, _ := filepath.Glob(pattern)
-		if len(paths) != 0 {
-			return paths[0]
+
+	return printExtensions(m, exts, opts.format)
+}
+
+// printExtensions prints extension files in the specified format.
+func printExtensions(m *sqlpkg.Manager, exts []sqlpkg.Extension, format string) error {
+	switch format {
+	case formatJSON:
+		data, err := json.MarshalIndent(exts, "", "    ")
+		if err != nil {
+			return err
+		}
+		m.Logger.Log(string(data))
+	case formatSQL:
+		for _, ext := range exts {
+			m.Logger.Log(ext.LoadStatement())
+		}
+	case formatDot:
+		for _, ext := range exts {
+			m.Logger.Log(ext.LoadCommand())
+		}
+	default:
+		for _, ext := range exts {
+			m.Logger.Log(ext.Path)
 		}
 	}
-	// no exact match
-	return ""
+	return nil
 }
 
-// findByExt returns paths to files in the package dir
-// that have an expected extension (e.g. textext.dylib)
-func findByExt(pkgDir, name, os string) []string {
-	file := "*" + fileExt[os]
-	pattern := filepath.Join(pkgDir, file)
-	paths, _ := filepath.Glob(pattern)
-	return paths
+// printEntrypoints prints extension entry points exported by the library file.
+func printEntrypoints(m *sqlpkg.Manager, pkg *spec.Package, path string) error {
+	entries, err := m.Entrypoints(pkg, path)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return errors.New("entry points are not found")
+	}
+	for _, entry := range entries {
+		m.Logger.Log(entry)
+	}
+	return nil
+}
+
+// verifyFiles checks installed package files against the package manifest.
+func verifyFiles(m *sqlpkg.Manager, pkg *spec.Package) error {
+	report, err := m.VerifyFiles(pkg)
+	if err != nil {
+		return err
+	}
+	if !report.OK() {
+		return fmt.Errorf("package files have been modified, run `sqlpkg verify %s` for details", pkg.FullName())
+	}
+	return nil
 }
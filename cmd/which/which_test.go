@@ -1,22 +1,26 @@
 package which
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
-	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/internal/sqlpkgtest"
 	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/manifest"
+	"sqlpkg.org/cli/spec"
 )
 
 func TestExact(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
 
 	t.Run("exact", func(t *testing.T) {
-		mem := logx.Mock()
+		mem := logx.Mock(m.Logger)
 		args := []string{"nalgeon/example"}
-		err := Which(args)
+		err := Which(m, args)
 		if err != nil {
 			t.Fatalf("which error: %v", err)
 		}
@@ -26,9 +30,9 @@ func TestExact(t *testing.T) {
 		mem.MustNotHave(t, "exact match not found")
 	})
 	t.Run("version", func(t *testing.T) {
-		mem := logx.Mock()
+		mem := logx.Mock(m.Logger)
 		args := []string{"nalgeon/version"}
-		err := Which(args)
+		err := Which(m, args)
 		if err != nil {
 			t.Fatalf("which error: %v", err)
 		}
@@ -38,9 +42,9 @@ func TestExact(t *testing.T) {
 		mem.MustNotHave(t, "exact match not found")
 	})
 	t.Run("prefix", func(t *testing.T) {
-		mem := logx.Mock()
+		mem := logx.Mock(m.Logger)
 		args := []string{"nalgeon/prefix"}
-		err := Which(args)
+		err := Which(m, args)
 		if err != nil {
 			t.Fatalf("which error: %v", err)
 		}
@@ -52,13 +56,13 @@ func TestExact(t *testing.T) {
 }
 
 func TestPossible(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
-	mem := logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
 
 	args := []string{"sqlite/stmt"}
-	err := Which(args)
+	err := Which(m, args)
 	if err != nil {
 		t.Fatalf("which error: %v", err)
 	}
@@ -69,13 +73,13 @@ func TestPossible(t *testing.T) {
 }
 
 func TestNotFound(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	cmd.CopyTestRepo(t, "")
-	logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	logx.Mock(m.Logger)
 
 	args := []string{"sqlite/broken"}
-	err := Which(args)
+	err := Which(m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -85,12 +89,12 @@ func TestNotFound(t *testing.T) {
 }
 
 func TestUnknown(t *testing.T) {
-	cmd.SetupTestRepo(t)
-	defer cmd.TeardownTestRepo(t)
-	logx.Mock()
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	logx.Mock(m.Logger)
 
 	args := []string{"sqlite/unknown"}
-	err := Which(args)
+	err := Which(m, args)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -98,3 +102,186 @@ func TestUnknown(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestVerify(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("missing manifest", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		args := []string{"--verify", "nalgeon/example"}
+		err := Which(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "package manifest is missing") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	t.Run("intact", func(t *testing.T) {
+		pkgDir := spec.Dir(m.Dir, "nalgeon", "example")
+		mf, err := manifest.Build(pkgDir)
+		if err != nil {
+			t.Fatalf("manifest.Build: unexpected error %v", err)
+		}
+		err = mf.Save(pkgDir)
+		if err != nil {
+			t.Fatalf("Manifest.Save: unexpected error %v", err)
+		}
+
+		mem := logx.Mock(m.Logger)
+		args := []string{"--verify", "nalgeon/example"}
+		err = Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+		mem.MustHave(t, ".sqlpkg/nalgeon/example/example")
+	})
+	t.Run("modified", func(t *testing.T) {
+		path := filepath.Join(spec.Dir(m.Dir, "nalgeon", "example"), "example.so")
+		err := os.WriteFile(path, []byte("tampered"), 0644)
+		if err != nil {
+			t.Fatalf("WriteFile: unexpected error %v", err)
+		}
+
+		logx.Mock(m.Logger)
+		args := []string{"--verify", "nalgeon/example"}
+		err = Which(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "package files have been modified") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEntrypoints(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("from file", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"--entrypoints", "nalgeon/entry"}
+		err := Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "entry points are not recorded")
+		mem.MustHave(t, "sqlite3_example_init")
+	})
+	t.Run("recorded", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"--entrypoints", "nalgeon/recorded"}
+		err := Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "sqlite3_recorded_init")
+		mem.MustNotHave(t, ".sqlpkg/nalgeon/recorded")
+	})
+	t.Run("not found", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		args := []string{"--entrypoints", "nalgeon/example"}
+		err := Which(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "entry points are not found") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestFormat(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+
+	t.Run("sql", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"--format=sql", "nalgeon/recorded"}
+		err := Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "select load_extension('.sqlpkg/nalgeon/recorded/recorded")
+		mem.MustHave(t, "', 'sqlite3_recorded_init');")
+	})
+	t.Run("dot", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"--format=dot", "nalgeon/recorded"}
+		err := Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, ".load .sqlpkg/nalgeon/recorded/recorded")
+		mem.MustHave(t, " sqlite3_recorded_init")
+	})
+	t.Run("no entry point", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"--format=sql", "nalgeon/example"}
+		err := Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, "select load_extension('.sqlpkg/nalgeon/example/example")
+		mem.MustNotHave(t, "', '")
+	})
+	t.Run("json", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		args := []string{"--format=json", "nalgeon/recorded"}
+		err := Which(m, args)
+		if err != nil {
+			t.Fatalf("which error: %v", err)
+		}
+
+		mem.Print()
+		mem.MustHave(t, `"package": "nalgeon/recorded"`)
+		mem.MustHave(t, `"path": ".sqlpkg/nalgeon/recorded/recorded`)
+		mem.MustHave(t, `"entrypoint": "sqlite3_recorded_init"`)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		args := []string{"--format=xml", "nalgeon/recorded"}
+		err := Which(m, args)
+		if err == nil {
+			t.Fatalf("expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "unknown format: xml") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAll(t *testing.T) {
+	m := sqlpkgtest.SetupRepo(t)
+	defer sqlpkgtest.TeardownRepo(t)
+	sqlpkgtest.CopyRepo(t, "")
+	mem := logx.Mock(m.Logger)
+
+	args := []string{"--all", "--format=dot"}
+	err := Which(m, args)
+	if err != nil {
+		t.Fatalf("which error: %v", err)
+	}
+
+	mem.Print()
+	mem.MustHave(t, ".load .sqlpkg/nalgeon/example/example")
+	mem.MustHave(t, ".load .sqlpkg/nalgeon/version/version0")
+	mem.MustHave(t, ".load .sqlpkg/sqlite/stmt/stmtvtab")
+	mem.MustHave(t, "sqlite3_example_init")
+	mem.MustNotHave(t, ".load .sqlpkg/sqlite/broken")
+}
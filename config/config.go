@@ -0,0 +1,75 @@
+// Package config manages sqlpkg settings (config.json).
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/provider"
+	"sqlpkg.org/cli/spec"
+)
+
+// FileName is the config filename.
+const FileName = "config.json"
+
+// Path returns the path to the config file.
+func Path(basePath string) string {
+	return filepath.Join(basePath, spec.DirName, FileName)
+}
+
+// A Config describes sqlpkg settings for a scope.
+type Config struct {
+	// RequireChecksums fails installs of assets without a verifiable checksum.
+	RequireChecksums bool `json:"require_checksums,omitempty"`
+	// Loader describes the init script that loads installed extensions.
+	Loader *Loader `json:"loader,omitempty"`
+	// Registries resolve owner-name pairs to package specs.
+	// If empty, spec.DefaultRegistries are used.
+	Registries []spec.Registry `json:"registries,omitempty"`
+	// Tokens are bearer tokens for private registries and asset hosts,
+	// mapped by host name (e.g. pkg.example.org).
+	Tokens map[string]string `json:"tokens,omitempty"`
+	// CredentialHelpers are external programs that provide credentials,
+	// mapped by host name ("*" matches any host). See httpx.HelperCredentials.
+	CredentialHelpers map[string]string `json:"credential_helpers,omitempty"`
+	// Providers are self-hosted code hosting instances
+	// (GitHub Enterprise, GitLab, Gitea or Forgejo).
+	Providers []provider.Config `json:"providers,omitempty"`
+}
+
+// A Loader describes the sqlite3 init script that loads installed extensions.
+// Output is the path to the script. Include and Exclude are package name
+// globs (e.g. nalgeon/*) that select the packages to load.
+// If Auto is set, the script is regenerated after install, update and uninstall.
+type Loader struct {
+	Auto    bool     `json:"auto,omitempty"`
+	Output  string   `json:"output,omitempty"`
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Default returns the default settings.
+func Default() *Config {
+	return &Config{}
+}
+
+// Save writes the config to the specified base directory.
+func (c *Config) Save(basePath string) error {
+	data, err := json.MarshalIndent(c, "", "    ")
+	if err != nil {
+		return err
+	}
+	path := Path(basePath)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadLocal reads the config from a local file.
+func ReadLocal(path string) (*Config, error) {
+	return fileio.ReadJSON[Config](path)
+}
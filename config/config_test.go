@@ -0,0 +1,72 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sqlpkg.org/cli/spec"
+)
+
+func TestPath(t *testing.T) {
+	got := Path("testdata")
+	if got != filepath.Join("testdata", ".sqlpkg", FileName) {
+		t.Errorf("Path: unexpected value %v", got)
+	}
+}
+
+func TestDefault(t *testing.T) {
+	cfg := Default()
+	if cfg.RequireChecksums {
+		t.Errorf("Default: unexpected RequireChecksums %v", cfg.RequireChecksums)
+	}
+}
+
+func TestReadLocal(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cfg, err := ReadLocal(filepath.Join("testdata", FileName))
+		if err != nil {
+			t.Fatalf("ReadLocal: unexpected error %v", err)
+		}
+		if !cfg.RequireChecksums {
+			t.Errorf("ReadLocal: unexpected RequireChecksums %v", cfg.RequireChecksums)
+		}
+		want := &Loader{Auto: true, Output: "init.sql", Exclude: []string{"sqlite/*"}}
+		if !reflect.DeepEqual(cfg.Loader, want) {
+			t.Errorf("ReadLocal: unexpected Loader %+v", cfg.Loader)
+		}
+		registries := []spec.Registry{
+			{Name: "internal", URL: "https://example.org/pkg/{owner}/{name}.json", Priority: 10},
+			{Name: "local", Dir: "registry"},
+		}
+		if !reflect.DeepEqual(cfg.Registries, registries) {
+			t.Errorf("ReadLocal: unexpected Registries %+v", cfg.Registries)
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		_, err := ReadLocal(filepath.Join("testdata", "missing.json"))
+		if err == nil {
+			t.Fatal("ReadLocal: expected error, got nil")
+		}
+	})
+}
+
+func TestConfig_Save(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &Config{
+		RequireChecksums: true,
+		Loader:           &Loader{Auto: true, Include: []string{"nalgeon/*"}},
+	}
+	err := cfg.Save(dir)
+	if err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	got, err := ReadLocal(Path(dir))
+	if err != nil {
+		t.Fatalf("ReadLocal: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got, cfg) {
+		t.Errorf("Save: unexpected decoded config %+v", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"errors"
+	"hash"
 	"io"
 	"os"
 	"os/exec"
@@ -80,19 +81,23 @@ func ReadJSON[T any](path string) (*T, error) {
 
 // CalcChecksum calculates the SHA-256 checksum of a file.
 func CalcChecksum(path string) ([]byte, error) {
+	return CalcHash(path, sha256.New())
+}
+
+// CalcHash calculates the checksum of a file using the given hash function.
+func CalcHash(path string, h hash.Hash) ([]byte, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	hash := sha256.New()
-	_, err = io.Copy(hash, file)
+	_, err = io.Copy(h, file)
 	if err != nil {
 		return nil, err
 	}
 
-	return hash.Sum(nil), nil
+	return h.Sum(nil), nil
 }
 
 // Dequarantine removes the macOS quarantine flag from a file.
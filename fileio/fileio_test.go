@@ -1,6 +1,7 @@
 package fileio
 
 import (
+	"crypto/sha512"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -228,6 +229,34 @@ func TestCalcChecksum(t *testing.T) {
 	})
 }
 
+func TestCalcHash(t *testing.T) {
+	t.Run("sha512", func(t *testing.T) {
+		dir := t.TempDir()
+		path := createFileWithContents(t, dir, "example.txt", "example.txt")
+
+		sum, err := CalcHash(path, sha512.New())
+		if err != nil {
+			t.Fatalf("CalcHash: unexpected error %v", err)
+		}
+		if len(sum) != 512/8 {
+			t.Fatalf("CalcHash: unxpected length %d", len(sum))
+		}
+		want := sha512.Sum512([]byte("example.txt"))
+		if !reflect.DeepEqual(sum, want[:]) {
+			t.Fatalf("CalcHash: unexpected value %v", sum[:6])
+		}
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "missing.txt")
+
+		_, err := CalcHash(path, sha512.New())
+		if err == nil {
+			t.Fatal("CalcHash: expected error, got nil")
+		}
+	})
+}
+
 func createDir(t *testing.T, name string) string {
 	parent := t.TempDir()
 	dir := filepath.Join(parent, name)
@@ -0,0 +1,243 @@
+package httpx
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// githubHosts accept GitHub tokens.
+var githubHosts = []string{"github.com", "api.github.com", "raw.githubusercontent.com"}
+
+// A Credential authenticates requests to a host, either
+// with a bearer Token or with a Username and Password.
+// Source describes where the credential came from (e.g. GITHUB_TOKEN)
+// and is safe to print, unlike the other fields.
+type Credential struct {
+	Username string
+	Password string
+	Token    string
+	Source   string
+}
+
+// apply sets the request authorization header.
+func (c *Credential) apply(req *http.Request) {
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+		return
+	}
+	req.SetBasicAuth(c.Username, c.Password)
+}
+
+// String implements the fmt.Stringer interface.
+// Never reveals the secrets, so it's safe to log.
+func (c *Credential) String() string {
+	if c.Token != "" {
+		return "token from " + c.Source
+	}
+	return "password for " + c.Username + " from " + c.Source
+}
+
+// A CredentialSource returns the credential for the url,
+// or nil if it has none.
+type CredentialSource func(u *url.URL) (*Credential, error)
+
+// An AuthClient adds credentials to requests. Sources are tried in order,
+// and the first credential found is used. Debug, if set, receives
+// debug messages (without the secrets).
+type AuthClient struct {
+	Client  Client
+	Sources []CredentialSource
+	Debug   func(message string, args ...any)
+}
+
+// NewAuthClient creates a client that authenticates
+// the requests of the underlying client using the sources.
+func NewAuthClient(client Client, sources ...CredentialSource) *AuthClient {
+	return &AuthClient{Client: client, Sources: sources}
+}
+
+// Do adds credentials to the request (unless it already has some)
+// and sends it. Credentials are not sent over plain HTTP,
+// except to the localhost.
+func (c *AuthClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Authorization") != "" || !isSecure(req.URL) {
+		return c.Client.Do(req)
+	}
+	for _, source := range c.Sources {
+		cred, err := source(req.URL)
+		if err != nil {
+			return nil, err
+		}
+		if cred == nil {
+			continue
+		}
+		c.debug("using %s for %s", cred, req.URL.Hostname())
+		req = req.Clone(req.Context())
+		cred.apply(req)
+		break
+	}
+	return c.Client.Do(req)
+}
+
+// debug prints a debug message if there is a receiver.
+func (c *AuthClient) debug(message string, args ...any) {
+	if c.Debug != nil {
+		c.Debug(message, args...)
+	}
+}
+
+// isSecure checks if it's safe to send credentials to the url.
+func isSecure(u *url.URL) bool {
+	if u.Scheme == "https" {
+		return true
+	}
+	host := u.Hostname()
+	return host == "localhost" || host == "127.0.0.1" || host == "::1"
+}
+
+// GitHubToken returns the GitHub token and the name of the environment
+// variable it came from (GITHUB_TOKEN or GH_TOKEN).
+// Returns empty strings if neither is set.
+func GitHubToken() (token string, name string) {
+	for _, name := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(name); token != "" {
+			return token, name
+		}
+	}
+	return "", ""
+}
+
+// GitHubCredentials returns the GitHub token (if set)
+// for GitHub hosts (github.com, api.github.com and raw.githubusercontent.com).
+func GitHubCredentials(u *url.URL) (*Credential, error) {
+	token, name := GitHubToken()
+	if token == "" {
+		return nil, nil
+	}
+	host := u.Hostname()
+	for _, ghHost := range githubHosts {
+		if host == ghHost {
+			return &Credential{Token: token, Source: name}, nil
+		}
+	}
+	return nil, nil
+}
+
+// HostTokens returns a source of bearer tokens mapped by host name.
+// The tokens function is called on each request, so it may load them lazily.
+func HostTokens(tokens func() (map[string]string, error), source string) CredentialSource {
+	return func(u *url.URL) (*Credential, error) {
+		hostTokens, err := tokens()
+		if err != nil {
+			return nil, err
+		}
+		token, ok := hostTokens[u.Hostname()]
+		if !ok || token == "" {
+			return nil, nil
+		}
+		return &Credential{Token: token, Source: source}, nil
+	}
+}
+
+// Netrc returns a source of credentials from the netrc file
+// (see NetrcPath). The file is read once, on the first request.
+// Missing or unreadable file means no credentials.
+func Netrc(path string) CredentialSource {
+	var once sync.Once
+	var machines []netrcMachine
+	return func(u *url.URL) (*Credential, error) {
+		once.Do(func() {
+			data, err := os.ReadFile(path)
+			if err == nil {
+				machines = parseNetrc(string(data))
+			}
+		})
+		host := u.Hostname()
+		for _, m := range machines {
+			if m.name == host || m.name == "" {
+				return &Credential{Username: m.login, Password: m.password, Source: "netrc"}, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+// NetrcPath returns the path to the netrc file: either from
+// the NETRC environment variable or in the user's home directory.
+func NetrcPath() string {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "_netrc")
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// netrcMachine is a netrc entry.
+// The default entry has an empty name.
+type netrcMachine struct {
+	name     string
+	login    string
+	password string
+}
+
+// parseNetrc parses the netrc file contents.
+// The default entry (if any) is always the last one.
+func parseNetrc(data string) []netrcMachine {
+	var machines []netrcMachine
+	var current *netrcMachine
+	var defaults *netrcMachine
+	inMacro := false
+	for _, line := range strings.Split(data, "\n") {
+		if inMacro {
+			// macro definitions end with an empty line
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			value := ""
+			if i+1 < len(fields) {
+				value = fields[i+1]
+			}
+			switch fields[i] {
+			case "machine":
+				machines = append(machines, netrcMachine{name: value})
+				current = &machines[len(machines)-1]
+				i++
+			case "default":
+				defaults = &netrcMachine{}
+				current = defaults
+			case "login":
+				if current != nil {
+					current.login = value
+				}
+				i++
+			case "password":
+				if current != nil {
+					current.password = value
+				}
+				i++
+			case "account":
+				i++
+			case "macdef":
+				inMacro = true
+				i = len(fields)
+			}
+		}
+	}
+	if defaults != nil {
+		machines = append(machines, *defaults)
+	}
+	return machines
+}
@@ -0,0 +1,143 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordClient records the requests it gets
+// and responds with an empty 200 OK.
+type recordClient struct {
+	req *http.Request
+}
+
+func (c *recordClient) Do(req *http.Request) (*http.Response, error) {
+	c.req = req
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestAuthClient(t *testing.T) {
+	tokens := func() (map[string]string, error) {
+		return map[string]string{"pkg.example.org": "secret"}, nil
+	}
+	rec := &recordClient{}
+	client := NewAuthClient(rec, HostTokens(tokens, "config"))
+	logged := []string{}
+	client.Debug = func(message string, args ...any) {
+		logged = append(logged, message)
+		for _, arg := range args {
+			if s, ok := arg.(interface{ String() string }); ok {
+				logged = append(logged, s.String())
+			}
+		}
+	}
+
+	t.Run("token", func(t *testing.T) {
+		_, err := GetBytes(context.Background(), client, "https://pkg.example.org/spec.json")
+		if err != nil {
+			t.Fatalf("GetBytes: unexpected error %v", err)
+		}
+		if got := rec.req.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected authorization %q", got)
+		}
+		if strings.Contains(strings.Join(logged, " "), "secret") {
+			t.Errorf("secret is logged: %v", logged)
+		}
+	})
+	t.Run("other host", func(t *testing.T) {
+		_, err := GetBytes(context.Background(), client, "https://example.org/spec.json")
+		if err != nil {
+			t.Fatalf("GetBytes: unexpected error %v", err)
+		}
+		if got := rec.req.Header.Get("Authorization"); got != "" {
+			t.Errorf("unexpected authorization %q", got)
+		}
+	})
+	t.Run("plain http", func(t *testing.T) {
+		_, err := GetBytes(context.Background(), client, "http://pkg.example.org/spec.json")
+		if err != nil {
+			t.Fatalf("GetBytes: unexpected error %v", err)
+		}
+		if got := rec.req.Header.Get("Authorization"); got != "" {
+			t.Errorf("unexpected authorization %q", got)
+		}
+	})
+}
+
+func TestGitHubCredentials(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "gh-secret")
+
+	u, _ := url.Parse("https://api.github.com/repos/nalgeon/sqlean/releases/latest")
+	cred, err := GitHubCredentials(u)
+	if err != nil {
+		t.Fatalf("GitHubCredentials: unexpected error %v", err)
+	}
+	if cred == nil || cred.Token != "gh-secret" || cred.Source != "GH_TOKEN" {
+		t.Fatalf("GitHubCredentials: unexpected credential %v", cred)
+	}
+	if strings.Contains(cred.String(), "gh-secret") {
+		t.Errorf("String reveals the token: %s", cred)
+	}
+
+	u, _ = url.Parse("https://example.org/sqlpkg.json")
+	cred, _ = GitHubCredentials(u)
+	if cred != nil {
+		t.Errorf("GitHubCredentials: unexpected credential for %s", u)
+	}
+}
+
+func TestNetrc(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".netrc")
+	data := `machine pkg.example.org
+    login alice
+    password secret
+
+macdef init
+machine ignored.org login bob
+
+machine files.example.org login carol password other
+default login anonymous password guest
+`
+	err := os.WriteFile(path, []byte(data), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: unexpected error %v", err)
+	}
+
+	source := Netrc(path)
+	tests := []struct {
+		url  string
+		user string
+		pass string
+	}{
+		{"https://pkg.example.org/spec.json", "alice", "secret"},
+		{"https://files.example.org/example.zip", "carol", "other"},
+		{"https://ignored.org/spec.json", "anonymous", "guest"},
+	}
+	for _, test := range tests {
+		u, _ := url.Parse(test.url)
+		cred, err := source(u)
+		if err != nil {
+			t.Fatalf("Netrc(%s): unexpected error %v", test.url, err)
+		}
+		got := []string{cred.Username, cred.Password}
+		if !reflect.DeepEqual(got, []string{test.user, test.pass}) {
+			t.Errorf("Netrc(%s): unexpected credential %v", test.url, got)
+		}
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		source := Netrc(filepath.Join(t.TempDir(), ".netrc"))
+		u, _ := url.Parse("https://pkg.example.org/spec.json")
+		cred, err := source(u)
+		if err != nil || cred != nil {
+			t.Errorf("Netrc: unexpected result %v, %v", cred, err)
+		}
+	})
+}
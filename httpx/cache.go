@@ -0,0 +1,207 @@
+package httpx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A CacheClient caches responses to GET requests on disk.
+// Cached responses are served until they expire according to
+// the Cache-Control (or Expires) header, then revalidated using
+// conditional requests (If-None-Match and If-Modified-Since).
+// If the network fails (or the rate limit is exceeded),
+// serves stale responses instead.
+// Binary downloads (Accept: application/octet-stream), authenticated
+// requests and private (or no-store) responses are not cached.
+type CacheClient struct {
+	Client Client
+	Dir    string
+	// Now returns the current time. Used for testing.
+	Now func() time.Time
+}
+
+// cacheEntry is a cached response.
+type cacheEntry struct {
+	URL     string      `json:"url"`
+	Header  http.Header `json:"header"`
+	Body    []byte      `json:"body"`
+	Expires time.Time   `json:"expires"`
+}
+
+// NewCacheClient creates a client that caches
+// the responses of the underlying client in the dir.
+func NewCacheClient(client Client, dir string) *CacheClient {
+	return &CacheClient{Client: client, Dir: dir, Now: time.Now}
+}
+
+// Do sends the request or serves the response from the cache.
+func (c *CacheClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Accept") == "application/octet-stream" ||
+		req.Header.Get("Authorization") != "" {
+		return c.Client.Do(req)
+	}
+
+	path := c.entryPath(req)
+	entry := c.load(path)
+	if entry != nil && c.Now().Before(entry.Expires) {
+		return entry.response(req), nil
+	}
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := entry.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		if entry != nil && req.Context().Err() == nil {
+			// the network failed, use stale data
+			return entry.response(req), nil
+		}
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		resp.Body.Close()
+		entry.Expires = c.expires(resp.Header)
+		c.save(path, entry)
+		return entry.response(req), nil
+	case (resp.StatusCode >= http.StatusInternalServerError || checkRateLimit(resp) != nil) && entry != nil:
+		// the server failed or refused, use stale data
+		resp.Body.Close()
+		return entry.response(req), nil
+	case resp.StatusCode != http.StatusOK:
+		return resp, nil
+	}
+
+	if hasDirective(resp.Header, "no-store") || hasDirective(resp.Header, "private") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	entry = &cacheEntry{
+		URL:     req.URL.String(),
+		Header:  resp.Header,
+		Body:    body,
+		Expires: c.expires(resp.Header),
+	}
+	c.save(path, entry)
+	return entry.response(req), nil
+}
+
+// entryPath returns the path to the cached response for the request.
+func (c *CacheClient) entryPath(req *http.Request) string {
+	key := req.URL.String() + "\n" + req.Header.Get("Accept")
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// expires returns the time the response with the headers
+// should be revalidated. Responses without explicit
+// expiration are revalidated on each request.
+func (c *CacheClient) expires(header http.Header) time.Time {
+	now := c.Now()
+	if hasDirective(header, "no-cache") {
+		return now
+	}
+	if maxAge, ok := directiveValue(header, "max-age"); ok {
+		seconds, err := strconv.Atoi(maxAge)
+		if err != nil {
+			return now
+		}
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+	if expires, err := http.ParseTime(header.Get("Expires")); err == nil {
+		return expires
+	}
+	return now
+}
+
+// load reads the cached response from the file.
+// Returns nil if there is none or it's unreadable.
+func (c *CacheClient) load(path string) *cacheEntry {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry cacheEntry
+	err = json.Unmarshal(data, &entry)
+	if err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// save writes the response to the cache file. Caching is an optimization,
+// so failing to save is not an error.
+func (c *CacheClient) save(path string, entry *cacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	err = os.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return
+	}
+	err = os.Rename(tmp, path)
+	if err != nil {
+		os.Remove(tmp)
+	}
+}
+
+// response creates a response to the request from the cached entry.
+func (e *cacheEntry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// hasDirective checks if the Cache-Control header has the directive.
+func hasDirective(header http.Header, name string) bool {
+	_, ok := directiveValue(header, name)
+	return ok
+}
+
+// directiveValue returns the value of the Cache-Control directive.
+func directiveValue(header http.Header, name string) (string, bool) {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if strings.EqualFold(key, name) {
+			return strings.Trim(value, `"`), true
+		}
+	}
+	return "", false
+}
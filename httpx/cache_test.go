@@ -0,0 +1,210 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// cacheServer serves a JSON document with the given Cache-Control header
+// and an ETag, and counts the requests it gets.
+type cacheServer struct {
+	*httptest.Server
+	cacheControl string
+	body         string
+	requests     int
+	revalidated  int
+	fail         bool
+}
+
+func newCacheServer(cacheControl string) *cacheServer {
+	srv := &cacheServer{cacheControl: cacheControl, body: `{"name":"v1"}`}
+	srv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.requests += 1
+		if srv.fail {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		etag := `"` + srv.body + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			srv.revalidated += 1
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag)
+		if srv.cacheControl != "" {
+			w.Header().Set("Cache-Control", srv.cacheControl)
+		}
+		_, _ = w.Write([]byte(srv.body))
+	}))
+	return srv
+}
+
+type named struct {
+	Name string `json:"name"`
+}
+
+func getName(t *testing.T, client Client, url string) string {
+	val, err := GetJSON[named](context.Background(), client, url)
+	if err != nil {
+		t.Fatalf("GetJSON: unexpected error %v", err)
+	}
+	return val.Name
+}
+
+func TestCacheClient(t *testing.T) {
+	t.Run("max-age", func(t *testing.T) {
+		srv := newCacheServer("max-age=60")
+		defer srv.Close()
+		now := time.Now()
+		client := NewCacheClient(srv.Client(), t.TempDir())
+		client.Now = func() time.Time { return now }
+
+		getName(t, client, srv.URL)
+		srv.body = `{"name":"v2"}`
+		if name := getName(t, client, srv.URL); name != "v1" {
+			t.Errorf("expected cached v1, got %s", name)
+		}
+		if srv.requests != 1 {
+			t.Errorf("expected 1 request, got %d", srv.requests)
+		}
+
+		now = now.Add(2 * time.Minute)
+		if name := getName(t, client, srv.URL); name != "v2" {
+			t.Errorf("expected fresh v2, got %s", name)
+		}
+		if srv.requests != 2 {
+			t.Errorf("expected 2 requests, got %d", srv.requests)
+		}
+	})
+	t.Run("revalidate", func(t *testing.T) {
+		srv := newCacheServer("")
+		defer srv.Close()
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		getName(t, client, srv.URL)
+		if name := getName(t, client, srv.URL); name != "v1" {
+			t.Errorf("expected v1, got %s", name)
+		}
+		if srv.requests != 2 || srv.revalidated != 1 {
+			t.Errorf("expected 2 requests with 1 revalidation, got %d and %d", srv.requests, srv.revalidated)
+		}
+	})
+	t.Run("no-store", func(t *testing.T) {
+		srv := newCacheServer("no-store")
+		defer srv.Close()
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		getName(t, client, srv.URL)
+		getName(t, client, srv.URL)
+		if srv.revalidated != 0 {
+			t.Errorf("expected no revalidations, got %d", srv.revalidated)
+		}
+	})
+	t.Run("private", func(t *testing.T) {
+		srv := newCacheServer("private, max-age=60")
+		defer srv.Close()
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		getName(t, client, srv.URL)
+		getName(t, client, srv.URL)
+		if srv.requests != 2 {
+			t.Errorf("expected 2 requests, got %d", srv.requests)
+		}
+	})
+	t.Run("authorization", func(t *testing.T) {
+		srv := newCacheServer("max-age=60")
+		defer srv.Close()
+		dir := t.TempDir()
+		client := NewCacheClient(srv.Client(), dir)
+
+		for range 2 {
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+			req.Header.Set("Authorization", "Bearer secret")
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: unexpected error %v", err)
+			}
+			resp.Body.Close()
+		}
+		if srv.requests != 2 {
+			t.Errorf("expected 2 requests, got %d", srv.requests)
+		}
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 0 {
+			t.Errorf("expected no cached responses, got %d", len(entries))
+		}
+	})
+	t.Run("file mode", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("file modes are not supported on windows")
+		}
+		srv := newCacheServer("max-age=60")
+		defer srv.Close()
+		dir := t.TempDir()
+		client := NewCacheClient(srv.Client(), dir)
+
+		getName(t, client, srv.URL)
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 cached response, got %d", len(entries))
+		}
+		info, _ := entries[0].Info()
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("unexpected file mode %v", info.Mode().Perm())
+		}
+	})
+	t.Run("stale on server error", func(t *testing.T) {
+		srv := newCacheServer("no-cache")
+		defer srv.Close()
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		getName(t, client, srv.URL)
+		srv.fail = true
+		if name := getName(t, client, srv.URL); name != "v1" {
+			t.Errorf("expected stale v1, got %s", name)
+		}
+	})
+	t.Run("stale on network error", func(t *testing.T) {
+		srv := newCacheServer("no-cache")
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		getName(t, client, srv.URL)
+		srv.Close()
+		if name := getName(t, client, srv.URL); name != "v1" {
+			t.Errorf("expected stale v1, got %s", name)
+		}
+	})
+	t.Run("no cache on error", func(t *testing.T) {
+		srv := newCacheServer("")
+		defer srv.Close()
+		srv.fail = true
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		_, err := GetJSON[named](context.Background(), client, srv.URL)
+		if err == nil {
+			t.Fatal("GetJSON: expected error, got nil")
+		}
+	})
+	t.Run("binary", func(t *testing.T) {
+		srv := newCacheServer("max-age=60")
+		defer srv.Close()
+		client := NewCacheClient(srv.Client(), t.TempDir())
+
+		for range 2 {
+			body, err := GetBody(context.Background(), client, srv.URL, "application/octet-stream")
+			if err != nil {
+				t.Fatalf("GetBody: unexpected error %v", err)
+			}
+			body.Close()
+		}
+		if srv.requests != 2 {
+			t.Errorf("expected 2 requests, got %d", srv.requests)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package httpx
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// HelperPrefix is the prefix of credential helper program names.
+const HelperPrefix = "sqlpkg-credential-"
+
+// HelperCredentials returns a source of credentials from external
+// helper programs, similar to git credential helpers.
+//
+// The helpers function maps host names to helper names, with "*"
+// matching any host. For a helper named sso, runs the sqlpkg-credential-sso
+// program (found in PATH) with the get argument. Helper names with
+// a path separator are rejected.
+//
+// The helper gets protocol, host and path as key=value lines on stdin,
+// and prints username, password and/or token the same way to stdout.
+// Empty output means no credential. The credentials are cached
+// by host for the lifetime of the source.
+func HelperCredentials(helpers func() (map[string]string, error)) CredentialSource {
+	var mu sync.Mutex
+	cache := map[string]*Credential{}
+	return func(u *url.URL) (*Credential, error) {
+		hostHelpers, err := helpers()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := hostHelpers[u.Hostname()]
+		if !ok {
+			name, ok = hostHelpers["*"]
+		}
+		if !ok || name == "" {
+			return nil, nil
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		key := name + "\n" + u.Host
+		if cred, ok := cache[key]; ok {
+			return cred, nil
+		}
+		cred, err := runHelper(name, u)
+		if err != nil {
+			return nil, err
+		}
+		cache[key] = cred
+		return cred, nil
+	}
+}
+
+// runHelper asks the helper program for the url credential.
+func runHelper(name string, u *url.URL) (*Credential, error) {
+	if strings.ContainsRune(name, '/') || strings.ContainsRune(name, os.PathSeparator) {
+		return nil, fmt.Errorf("invalid credential helper name: %s", name)
+	}
+	program := HelperPrefix + name
+
+	input := fmt.Sprintf("protocol=%s\nhost=%s\npath=%s\n\n", u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/"))
+	cmd := exec.Command(program, "get")
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential helper %s failed: %w", name, err)
+	}
+
+	cred := &Credential{Source: "credential helper " + name}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "username":
+			cred.Username = value
+		case "password":
+			cred.Password = value
+		case "token":
+			cred.Token = value
+		}
+	}
+	if cred.Token == "" && cred.Username == "" && cred.Password == "" {
+		return nil, nil
+	}
+	return cred, nil
+}
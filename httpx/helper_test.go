@@ -0,0 +1,108 @@
+package httpx
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// helperScript answers with a token for pkg.example.org
+// and with nothing for other hosts. Logs the requests to calls.log.
+const helperScript = `#!/bin/sh
+[ "$1" = "get" ] || exit 1
+input=$(cat)
+echo "$input" >> "$(dirname "$0")/calls.log"
+case "$input" in
+    *host=pkg.example.org*)
+        echo "username=alice"
+        echo "token=sso-secret"
+        ;;
+    *host=broken.example.org*)
+        exit 2
+        ;;
+esac
+`
+
+func TestHelperCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script helpers are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, HelperPrefix+"test")
+	err := os.WriteFile(path, []byte(helperScript), 0755)
+	if err != nil {
+		t.Fatalf("WriteFile: unexpected error %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	helpers := func() (map[string]string, error) {
+		return map[string]string{"*": "test", "github.com": ""}, nil
+	}
+	source := HelperCredentials(helpers)
+
+	t.Run("credential", func(t *testing.T) {
+		u, _ := url.Parse("https://pkg.example.org/nalgeon/example.json")
+		cred, err := source(u)
+		if err != nil {
+			t.Fatalf("HelperCredentials: unexpected error %v", err)
+		}
+		if cred == nil || cred.Username != "alice" || cred.Token != "sso-secret" {
+			t.Fatalf("HelperCredentials: unexpected credential %v", cred)
+		}
+		if cred.Source != "credential helper test" {
+			t.Errorf("HelperCredentials: unexpected source %q", cred.Source)
+		}
+
+		data, _ := os.ReadFile(filepath.Join(dir, "calls.log"))
+		want := "protocol=https\nhost=pkg.example.org\npath=nalgeon/example.json\n"
+		if !strings.Contains(string(data), want) {
+			t.Errorf("HelperCredentials: unexpected helper input %q", data)
+		}
+	})
+	t.Run("cached", func(t *testing.T) {
+		u, _ := url.Parse("https://pkg.example.org/nalgeon/other.json")
+		cred, err := source(u)
+		if err != nil || cred == nil {
+			t.Fatalf("HelperCredentials: unexpected result %v, %v", cred, err)
+		}
+		data, _ := os.ReadFile(filepath.Join(dir, "calls.log"))
+		if count := strings.Count(string(data), "host=pkg.example.org"); count != 1 {
+			t.Errorf("HelperCredentials: expected 1 helper call, got %d", count)
+		}
+	})
+	t.Run("no credential", func(t *testing.T) {
+		u, _ := url.Parse("https://example.org/spec.json")
+		cred, err := source(u)
+		if err != nil || cred != nil {
+			t.Errorf("HelperCredentials: unexpected result %v, %v", cred, err)
+		}
+	})
+	t.Run("no helper", func(t *testing.T) {
+		u, _ := url.Parse("https://github.com/nalgeon/sqlean")
+		cred, err := source(u)
+		if err != nil || cred != nil {
+			t.Errorf("HelperCredentials: unexpected result %v, %v", cred, err)
+		}
+	})
+	t.Run("failed", func(t *testing.T) {
+		u, _ := url.Parse("https://broken.example.org/spec.json")
+		_, err := source(u)
+		if err == nil || !strings.Contains(err.Error(), "credential helper test failed") {
+			t.Errorf("HelperCredentials: unexpected error %v", err)
+		}
+	})
+	t.Run("path", func(t *testing.T) {
+		helpers := func() (map[string]string, error) {
+			return map[string]string{"*": path}, nil
+		}
+		u, _ := url.Parse("https://pkg.example.org/spec.json")
+		_, err := HelperCredentials(helpers)(u)
+		if err == nil || !strings.Contains(err.Error(), "invalid credential helper name") {
+			t.Errorf("HelperCredentials: unexpected error %v", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@
 package httpx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,13 +11,16 @@ import (
 	"time"
 )
 
-var client = Client(&http.Client{Timeout: 3 * time.Second})
-
 // Client is something that can send HTTP requests.
 type Client interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// NewClient creates the default HTTP client.
+func NewClient() Client {
+	return &http.Client{Timeout: 3 * time.Second}
+}
+
 // IsURL checks if the path is an url.
 func IsURL(path string) bool {
 	u, err := url.Parse(path)
@@ -43,8 +47,8 @@ func Hostname(rawUrl string) string {
 }
 
 // Exists checks if the specified url exists.
-func Exists(url string) bool {
-	req, err := http.NewRequest(http.MethodHead, url, nil)
+func Exists(ctx context.Context, client Client, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
 	if err != nil {
 		return false
 	}
@@ -56,8 +60,8 @@ func Exists(url string) bool {
 }
 
 // GetBody issues a GET request with an Accept header and returns the response body.
-func GetBody(url string, accept string) (io.ReadCloser, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func GetBody(ctx context.Context, client Client, url string, accept string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +73,10 @@ func GetBody(url string, accept string) (io.ReadCloser, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if err := checkRateLimit(resp); err != nil {
+			return nil, err
+		}
 		return nil, fmt.Errorf("got http status %d", resp.StatusCode)
 	}
 
@@ -76,8 +84,8 @@ func GetBody(url string, accept string) (io.ReadCloser, error) {
 }
 
 // GetBytes issues a GET request and decodes the response as bytes.
-func GetBytes(url string) ([]byte, error) {
-	body, err := GetBody(url, "*/*")
+func GetBytes(ctx context.Context, client Client, url string) ([]byte, error) {
+	body, err := GetBody(ctx, client, url, "*/*")
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +94,8 @@ func GetBytes(url string) ([]byte, error) {
 }
 
 // GetJSON issues a GET request and decodes the response as JSON.
-func GetJSON[T any](url string) (*T, error) {
-	body, err := GetBody(url, "application/json")
+func GetJSON[T any](ctx context.Context, client Client, url string) (*T, error) {
+	body, err := GetBody(ctx, client, url, "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,8 @@
 package httpx
 
 import (
+	"context"
+	"errors"
 	"io"
 	"testing"
 )
@@ -50,13 +52,13 @@ func TestExists(t *testing.T) {
 	defer srv.Close()
 
 	t.Run("exists", func(t *testing.T) {
-		ok := Exists(srv.URL + "/sqlpkg.json")
+		ok := Exists(context.Background(), srv.Client(), srv.URL+"/sqlpkg.json")
 		if !ok {
 			t.Errorf("Exists: unexpected %v", ok)
 		}
 	})
 	t.Run("does not exist", func(t *testing.T) {
-		ok := Exists(srv.URL + "/missing.json")
+		ok := Exists(context.Background(), srv.Client(), srv.URL+"/missing.json")
 		if ok {
 			t.Errorf("Exists: unexpected %v", ok)
 		}
@@ -68,7 +70,7 @@ func TestGetBody(t *testing.T) {
 	defer srv.Close()
 
 	t.Run("success", func(t *testing.T) {
-		body, err := GetBody(srv.URL+"/example.txt", "text/plain")
+		body, err := GetBody(context.Background(), srv.Client(), srv.URL+"/example.txt", "text/plain")
 		if err != nil {
 			t.Errorf("GetBody: unexpected error %v", err)
 		}
@@ -84,11 +86,19 @@ func TestGetBody(t *testing.T) {
 		}
 	})
 	t.Run("failure", func(t *testing.T) {
-		_, err := GetBody(srv.URL+"/missing.txt", "text/plain")
+		_, err := GetBody(context.Background(), srv.Client(), srv.URL+"/missing.txt", "text/plain")
 		if err == nil {
 			t.Error("GetBody: expected error, got nil")
 		}
 	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := GetBody(ctx, srv.Client(), srv.URL+"/example.txt", "text/plain")
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("GetBody: unexpected error %v", err)
+		}
+	})
 }
 
 func TestGetBytes(t *testing.T) {
@@ -96,7 +106,7 @@ func TestGetBytes(t *testing.T) {
 	defer srv.Close()
 
 	t.Run("success", func(t *testing.T) {
-		data, err := GetBytes(srv.URL + "/example.txt")
+		data, err := GetBytes(context.Background(), srv.Client(), srv.URL+"/example.txt")
 		if err != nil {
 			t.Errorf("GetBytes: unexpected error %v", err)
 		}
@@ -105,7 +115,7 @@ func TestGetBytes(t *testing.T) {
 		}
 	})
 	t.Run("failure", func(t *testing.T) {
-		_, err := GetBytes(srv.URL + "/missing.txt")
+		_, err := GetBytes(context.Background(), srv.Client(), srv.URL+"/missing.txt")
 		if err == nil {
 			t.Error("GetBytes: expected error, got nil")
 		}
@@ -118,7 +128,7 @@ func TestGetJSON(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		type Example struct{ Body string }
-		ex, err := GetJSON[Example](srv.URL + "/example.json")
+		ex, err := GetJSON[Example](context.Background(), srv.Client(), srv.URL+"/example.json")
 		if err != nil {
 			t.Errorf("GetJSON: unexpected error %v", err)
 		}
@@ -128,7 +138,7 @@ func TestGetJSON(t *testing.T) {
 	})
 	t.Run("failure", func(t *testing.T) {
 		type Example struct{ Body string }
-		_, err := GetJSON[Example](srv.URL + "/example.txt")
+		_, err := GetJSON[Example](context.Background(), srv.Client(), srv.URL+"/example.txt")
 		if err == nil {
 			t.Error("GetJSON: expected error, got nil")
 		}
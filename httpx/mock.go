@@ -25,12 +25,11 @@ type MockClient struct {
 	dir string
 }
 
-// Mock creates a new MockClient and installs it instead of the default one.
+// Mock creates a new MockClient that serves files
+// from the testdata directory (or its subdirectory).
 func Mock(path ...string) *MockClient {
 	dir := filepath.Join("testdata", filepath.Join(path...))
-	c := &MockClient{dir: dir}
-	client = c
-	return c
+	return &MockClient{dir: dir}
 }
 
 // Do serves the file according to the request URL.
@@ -42,6 +41,7 @@ func (c *MockClient) Do(req *http.Request) (*http.Response, error) {
 		resp := http.Response{
 			Status:     http.StatusText(http.StatusNotFound),
 			StatusCode: http.StatusNotFound,
+			Body:       http.NoBody,
 		}
 		return &resp, nil
 	}
@@ -69,9 +69,9 @@ func respond(cType string, data []byte) io.Reader {
 	return &buf
 }
 
-// MockServer creates a mock HTTP server and installs its client
-// instead of the default one. Serves responses from the file system
-// instead of remote calls. Should be used for testing purposes only.
+// MockServer creates a mock HTTP server that serves responses
+// from the file system instead of remote calls. Use the server's
+// Client() to send requests. Should be used for testing purposes only.
 func MockServer() *httptest.Server {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		filename := filepath.Join("testdata", path.Base(r.URL.Path))
@@ -93,6 +93,5 @@ func MockServer() *httptest.Server {
 			panic(err)
 		}
 	}))
-	client = srv.Client()
 	return srv
 }
@@ -1,26 +1,29 @@
 package httpx
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestMockClient(t *testing.T) {
-	Mock()
+	client := Mock()
 	{
 		const url = "https://antonz.org/example.txt"
-		ok := Exists(url)
+		ok := Exists(context.Background(), client, url)
 		if !ok {
 			t.Errorf("Exists(%s) expected true, got false", url)
 		}
 	}
 	{
 		const url = "https://antonz.org/missing.txt"
-		ok := Exists(url)
+		ok := Exists(context.Background(), client, url)
 		if ok {
 			t.Errorf("Exists(%s) expected false, got true", url)
 		}
 	}
 	{
 		const url = "https://antonz.org/example.txt"
-		data, err := GetBytes(url)
+		data, err := GetBytes(context.Background(), client, url)
 		if err != nil {
 			t.Errorf("GetBytes: unexpected error %v", err)
 		}
@@ -30,7 +33,7 @@ func TestMockClient(t *testing.T) {
 	}
 	{
 		const url = "https://antonz.org/missing.txt"
-		_, err := GetBytes(url)
+		_, err := GetBytes(context.Background(), client, url)
 		if err == nil {
 			t.Error("GetBytes: expected error, got nil")
 		}
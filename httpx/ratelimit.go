@@ -0,0 +1,84 @@
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// A RateLimit is the request quota reported by the server
+// in the X-RateLimit-* headers (as GitHub API does).
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// ParseRateLimit extracts the rate limit from the response headers.
+// Returns false if the headers are missing or invalid.
+func ParseRateLimit(header http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return RateLimit{}, false
+	}
+	return RateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}, true
+}
+
+// String implements the fmt.Stringer interface.
+func (r RateLimit) String() string {
+	return fmt.Sprintf("%d of %d requests left, resets at %s",
+		r.Remaining, r.Limit, r.Reset.Local().Format(time.TimeOnly))
+}
+
+// A RateLimitError means the server refused the request
+// because the client has exhausted its rate limit.
+type RateLimitError struct {
+	RateLimit
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit of %d requests exceeded, resets at %s",
+		e.Limit, e.Reset.Local().Format(time.TimeOnly))
+}
+
+// checkRateLimit returns a RateLimitError if the response
+// was refused because of the exhausted rate limit.
+func checkRateLimit(resp *http.Response) error {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+	rl, ok := ParseRateLimit(resp.Header)
+	if !ok || rl.Remaining > 0 {
+		return nil
+	}
+	return &RateLimitError{rl}
+}
+
+// A RateLimitClient reports the rate limit quota
+// of the responses that have one to the Debug function.
+type RateLimitClient struct {
+	Client Client
+	Debug  func(message string, args ...any)
+}
+
+// Do sends the request and reports the rate limit quota.
+func (c *RateLimitClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if rl, ok := ParseRateLimit(resp.Header); ok && c.Debug != nil {
+		c.Debug("%s rate limit: %s", req.URL.Hostname(), rl)
+	}
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rateLimitServer(remaining string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", remaining)
+		w.Header().Set("X-RateLimit-Reset", "1767225600")
+		if remaining == "0" {
+			http.Error(w, "rate limit exceeded", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"v1"}`))
+	}))
+}
+
+func TestParseRateLimit(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "60")
+	header.Set("X-RateLimit-Remaining", "12")
+	header.Set("X-RateLimit-Reset", "1767225600")
+	rl, ok := ParseRateLimit(header)
+	if !ok {
+		t.Fatal("ParseRateLimit: expected ok")
+	}
+	want := RateLimit{Limit: 60, Remaining: 12, Reset: time.Unix(1767225600, 0)}
+	if rl != want {
+		t.Errorf("ParseRateLimit: expected %+v, got %+v", want, rl)
+	}
+
+	_, ok = ParseRateLimit(http.Header{})
+	if ok {
+		t.Error("ParseRateLimit: expected not ok for missing headers")
+	}
+}
+
+func TestRateLimitError(t *testing.T) {
+	srv := rateLimitServer("0")
+	defer srv.Close()
+
+	_, err := GetBytes(context.Background(), srv.Client(), srv.URL)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("GetBytes: unexpected error %v", err)
+	}
+	if rateErr.Limit != 60 || !rateErr.Reset.Equal(time.Unix(1767225600, 0)) {
+		t.Errorf("GetBytes: unexpected rate limit %+v", rateErr.RateLimit)
+	}
+	if !strings.HasPrefix(err.Error(), "rate limit of 60 requests exceeded, resets at ") {
+		t.Errorf("GetBytes: unexpected message %q", err.Error())
+	}
+}
+
+func TestRateLimitClient(t *testing.T) {
+	srv := rateLimitServer("59")
+	defer srv.Close()
+
+	logged := []string{}
+	client := &RateLimitClient{Client: srv.Client(), Debug: func(message string, args ...any) {
+		logged = append(logged, message)
+		for _, arg := range args {
+			if rl, ok := arg.(RateLimit); ok {
+				logged = append(logged, rl.String())
+			}
+		}
+	}}
+	_, err := GetBytes(context.Background(), client, srv.URL)
+	if err != nil {
+		t.Fatalf("GetBytes: unexpected error %v", err)
+	}
+	if len(logged) != 2 || !strings.HasPrefix(logged[1], "59 of 60 requests left") {
+		t.Errorf("RateLimitClient: unexpected log %v", logged)
+	}
+}
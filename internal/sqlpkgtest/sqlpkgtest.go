@@ -0,0 +1,79 @@
+// Package sqlpkgtest provides helpers for testing code
+// that works with the sqlpkg package manager.
+package sqlpkgtest
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/sqlpkg"
+)
+
+// Manager creates a manager for the working directory
+// that logs to stdout and serves HTTP requests
+// from the testdata folder.
+func Manager() *sqlpkg.Manager {
+	m := sqlpkg.New(".")
+	m.Client = httpx.Mock()
+	m.Logger = logx.NewLogger(os.Stdout)
+	return m
+}
+
+// SetupRepo deletes the .sqlpkg folder and the lockfile
+// from the working directory and returns a test manager for it.
+func SetupRepo(t *testing.T) *sqlpkg.Manager {
+	m := Manager()
+	err := os.RemoveAll(m.RepoDir())
+	if err != nil {
+		t.Fatalf("SetupRepo: %v", err)
+	}
+	err = os.RemoveAll(m.LockfilePath())
+	if err != nil {
+		t.Fatalf("SetupRepo: %v", err)
+	}
+	return m
+}
+
+// CopyRepo copies the .sqlpkg folder and the lockfile
+// from the testdata folder to the working directory.
+func CopyRepo(t *testing.T, path ...string) {
+	basePath := filepath.Join("testdata", filepath.Join(path...))
+
+	{
+		// copy `.sqlpkg` contents
+		path := filepath.Join(basePath, ".sqlpkg")
+		cmd := []string{"cp", "-r", path, "."}
+		err := exec.Command(cmd[0], cmd[1:]...).Run()
+		if err != nil {
+			t.Fatalf("%s: %v", strings.Join(cmd, " "), err)
+		}
+	}
+	{
+		// copy lockfile
+		path := filepath.Join(basePath, "sqlpkg.lock")
+		cmd := []string{"cp", path, "."}
+		err := exec.Command(cmd[0], cmd[1:]...).Run()
+		if err != nil {
+			t.Fatalf("%s: %v", strings.Join(cmd, " "), err)
+		}
+	}
+}
+
+// TeardownRepo deletes the .sqlpkg folder and the lockfile
+// from the working directory.
+func TeardownRepo(t *testing.T) {
+	m := sqlpkg.New(".")
+	err := os.RemoveAll(m.RepoDir())
+	if err != nil {
+		t.Fatalf("TeardownRepo: %v", err)
+	}
+	err = os.RemoveAll(m.LockfilePath())
+	if err != nil {
+		t.Fatalf("TeardownRepo: %v", err)
+	}
+}
@@ -37,11 +37,13 @@ func (lck *Lockfile) Has(fullName string) bool {
 // Add adds a package to the lockfile.
 func (lck *Lockfile) Add(pkg *spec.Package) {
 	p := spec.Package{
-		Owner:    pkg.Owner,
-		Name:     pkg.Name,
-		Version:  pkg.Version,
-		Specfile: pkg.Specfile,
-		Assets:   pkg.Assets,
+		Owner:     pkg.Owner,
+		Name:      pkg.Name,
+		Version:   pkg.Version,
+		Specfile:  pkg.Specfile,
+		Registry:  pkg.Registry,
+		Publickey: pkg.Publickey,
+		Assets:    pkg.Assets,
 	}
 	lck.Packages[pkg.FullName()] = &p
 }
@@ -16,6 +16,11 @@ func NewLogger(out io.Writer) *Logger {
 	return &Logger{out: out}
 }
 
+// Output returns the logger destination.
+func (l *Logger) Output() io.Writer {
+	return l.out
+}
+
 // SetOutput changes the logger destination.
 func (l *Logger) SetOutput(out io.Writer) {
 	l.out = out
@@ -30,6 +35,11 @@ func (l *Logger) Log(message string, args ...any) {
 	}
 }
 
+// Warn prints a warning message.
+func (l *Logger) Warn(message string, args ...any) {
+	l.Log("! "+message, args...)
+}
+
 // Debug prints a message if the verbose mode is on.
 func (l *Logger) Debug(message string, args ...any) {
 	if !l.IsVerbose {
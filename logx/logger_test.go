@@ -0,0 +1,105 @@
+package logx
+
+import "testing"
+
+func TestLogger_SetOutput(t *testing.T) {
+	l := NewLogger(nil)
+	mem := NewMemory("log")
+	l.SetOutput(mem)
+	if l.Output() != mem {
+		t.Errorf("SetOutput: unexpected value %v", l.Output())
+	}
+}
+
+func TestLogger_Verbose(t *testing.T) {
+	mem := NewMemory("log")
+	l := NewLogger(mem)
+	{
+		if l.IsVerbose {
+			t.Errorf("IsVerbose: expected false, got true")
+		}
+	}
+	{
+		l.IsVerbose = true
+		if !l.IsVerbose {
+			t.Errorf("IsVerbose: expected true, got false")
+		}
+	}
+	{
+		l.IsVerbose = false
+		if l.IsVerbose {
+			t.Errorf("IsVerbose: expected false, got true")
+		}
+	}
+}
+
+func TestLogger_Log(t *testing.T) {
+	mem := NewMemory("log")
+	l := NewLogger(mem)
+	{
+		l.Log("value: %d", 42)
+		if len(mem.Lines) != 1 {
+			t.Errorf("Log: expected line count %v", len(mem.Lines))
+		}
+		if !mem.Has("value: 42") {
+			t.Errorf("Log: expected output: %v", mem.Lines)
+		}
+	}
+	{
+		l.Log("value: %d", 84)
+		if len(mem.Lines) != 2 {
+			t.Errorf("Log: expected line count %v", len(mem.Lines))
+		}
+		if !mem.Has("value: 42") || !mem.Has("value: 84") {
+			t.Errorf("Log: expected output: %v", mem.Lines)
+		}
+	}
+}
+
+func TestLogger_Warn(t *testing.T) {
+	mem := NewMemory("log")
+	l := NewLogger(mem)
+	l.IsVerbose = false
+	l.Warn("value: %d", 42)
+	if len(mem.Lines) != 1 {
+		t.Errorf("Warn: expected line count %v", len(mem.Lines))
+	}
+	if !mem.Has("! value: 42") {
+		t.Errorf("Warn: expected output: %v", mem.Lines)
+	}
+}
+
+func TestLogger_Debug(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		mem := NewMemory("log")
+		l := NewLogger(mem)
+		l.IsVerbose = true
+		{
+			l.Debug("value: %d", 42)
+			if len(mem.Lines) != 1 {
+				t.Errorf("Log: expected line count %v", len(mem.Lines))
+			}
+			if !mem.Has("value: 42") {
+				t.Errorf("Log: expected output: %v", mem.Lines)
+			}
+		}
+		{
+			l.Debug("value: %d", 84)
+			if len(mem.Lines) != 2 {
+				t.Errorf("Log: expected line count %v", len(mem.Lines))
+			}
+			if !mem.Has("value: 42") || !mem.Has("value: 84") {
+				t.Errorf("Log: expected output: %v", mem.Lines)
+			}
+		}
+	})
+	t.Run("disabled", func(t *testing.T) {
+		mem := NewMemory("log")
+		l := NewLogger(mem)
+		l.IsVerbose = false
+		l.Debug("value: %d", 42)
+		if len(mem.Lines) != 0 {
+			t.Errorf("Log: expected line count %v", len(mem.Lines))
+		}
+	})
+}
@@ -1,69 +1,97 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"sqlpkg.org/cli/cmd"
+	"sqlpkg.org/cli/cmd/doctor"
+	"sqlpkg.org/cli/cmd/env"
+	"sqlpkg.org/cli/cmd/exec"
+	"sqlpkg.org/cli/cmd/gen"
 	"sqlpkg.org/cli/cmd/help"
 	"sqlpkg.org/cli/cmd/info"
 	init_ "sqlpkg.org/cli/cmd/init"
 	"sqlpkg.org/cli/cmd/install"
 	"sqlpkg.org/cli/cmd/list"
+	"sqlpkg.org/cli/cmd/loader"
+	"sqlpkg.org/cli/cmd/provides"
+	"sqlpkg.org/cli/cmd/search"
+	"sqlpkg.org/cli/cmd/trust"
 	"sqlpkg.org/cli/cmd/uninstall"
 	"sqlpkg.org/cli/cmd/update"
+	"sqlpkg.org/cli/cmd/verify"
 	"sqlpkg.org/cli/cmd/which"
-	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/sqlpkg"
 )
 
 var version = "main"
 
-func parseArgs() (command string, args []string) {
+// exitInterrupted is the exit code when the command
+// is interrupted by SIGINT or SIGTERM (128 + SIGINT).
+const exitInterrupted = 130
+
+func parseArgs() (command string, args []string, isVerbose bool) {
 	if len(os.Args) < 2 {
-		return "", nil
+		return "", nil, false
 	}
 
-	var isVerbose bool
 	if flag.Lookup("v") == nil {
-		flag.BoolVar(&isVerbose, "v", false, "verbose output")
+		flag.Bool("v", false, "verbose output")
 	}
 	flag.Parse()
 
-	logx.SetVerbose(isVerbose)
+	isVerbose = flag.Lookup("v").Value.String() == "true"
 	args = flag.Args()
 	command, args = args[0], args[1:]
 	return
 }
 
-func execCommand(command string, args []string) error {
+func execCommand(ctx context.Context, m *sqlpkg.Manager, command string, args []string) error {
 	if command == "" {
-		return help.Help(nil)
+		return help.Help(m.Logger, nil)
 	}
 
 	switch command {
 	case "init":
-		return init_.Init(args)
+		return init_.Init(m.Logger, args)
 	case "install":
-		if len(args) == 0 {
-			return install.InstallAll(args)
-		}
-		return install.Install(args)
+		return install.Install(ctx, m, args)
 	case "uninstall":
-		return uninstall.Uninstall(args)
+		return uninstall.Uninstall(m, args)
 	case "update":
-		if len(args) == 0 {
-			return update.UpdateAll(args)
-		}
-		return update.Update(args)
+		return update.Update(ctx, m, args)
 	case "list":
-		return list.List(args)
+		return list.List(m, args)
 	case "info":
-		return info.Info(args)
+		return info.Info(ctx, m, args)
+	case "provides":
+		return provides.Provides(ctx, m, args)
+	case "search":
+		return search.Search(ctx, m, args)
 	case "which":
-		return which.Which(args)
+		return which.Which(m, args)
+	case "loader":
+		return loader.Loader(m, args)
+	case "verify":
+		return verify.Verify(m, args)
+	case "doctor":
+		return doctor.Doctor(m, args)
+	case "env":
+		return env.Env(m, args)
+	case "exec":
+		return exec.Exec(m, args)
+	case "gen":
+		return gen.Gen(m, args)
+	case "trust":
+		return trust.Trust(m, args)
 	case "help":
-		return help.Help(args)
+		return help.Help(m.Logger, args)
 	case "version":
 		fmt.Println(version)
 		return nil
@@ -73,8 +101,27 @@ func execCommand(command string, args []string) error {
 }
 
 func main() {
-	command, args := parseArgs()
-	err := execCommand(command, args)
+	command, args, isVerbose := parseArgs()
+	m := cmd.NewManager()
+	m.Logger.IsVerbose = isVerbose
+
+	// cancel the command on Ctrl-C, and let the second Ctrl-C
+	// terminate the program right away
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+	err := execCommand(ctx, m, command, args)
+	stop()
+
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		// the command has already reported the failure
+		os.Exit(exitErr.ExitCode())
+	}
+	if err != nil && ctx.Err() != nil {
+		// the command has cleaned up after itself
+		fmt.Println("! interrupted")
+		os.Exit(exitInterrupted)
+	}
 	if err != nil {
 		fmt.Println("!", err)
 		os.Exit(1)
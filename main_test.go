@@ -31,7 +31,7 @@ func Test_parseArgs(t *testing.T) {
 	}
 	for _, test := range tests {
 		os.Args = test.in
-		cmd, args := parseArgs()
+		cmd, args, _ := parseArgs()
 		if cmd != test.cmd {
 			t.Errorf("parseArgs(%v) expected cmd = %s, got %s", test.in, test.cmd, cmd)
 		}
@@ -0,0 +1,163 @@
+// Package manifest manages the list of installed package files (manifest.json).
+package manifest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/spec"
+)
+
+// FileName is the manifest filename.
+const FileName = "manifest.json"
+
+// Path returns the path to the manifest file in the package directory.
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// A File describes an installed package file.
+// Path is relative to the package directory and uses forward slashes.
+type File struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// A Manifest describes installed package files.
+type Manifest struct {
+	Files []File `json:"files"`
+}
+
+// Build creates a manifest for files in the package directory.
+// Does not include the package spec and the manifest itself.
+func Build(dir string) (*Manifest, error) {
+	files := []File{}
+	err := walkFiles(dir, func(rel, path string, info fs.FileInfo) error {
+		file, err := describe(rel, path, info)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Manifest{Files: files}, nil
+}
+
+// Save writes the manifest to the package directory.
+func (m *Manifest) Save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(dir), data, 0644)
+}
+
+// A Report describes differences between the manifest
+// and the actual files in the package directory.
+type Report struct {
+	Modified []string
+	Missing  []string
+	Extra    []string
+}
+
+// OK checks if the package files match the manifest.
+func (r *Report) OK() bool {
+	return len(r.Modified) == 0 && len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+// Verify compares files in the package directory against the manifest.
+func (m *Manifest) Verify(dir string) (*Report, error) {
+	report := &Report{Modified: []string{}, Missing: []string{}, Extra: []string{}}
+
+	expected := make(map[string]File, len(m.Files))
+	for _, file := range m.Files {
+		expected[file.Path] = file
+	}
+
+	seen := map[string]bool{}
+	err := walkFiles(dir, func(rel, path string, info fs.FileInfo) error {
+		want, ok := expected[rel]
+		if !ok {
+			report.Extra = append(report.Extra, rel)
+			return nil
+		}
+		seen[rel] = true
+		if info.Size() != want.Size {
+			report.Modified = append(report.Modified, rel)
+			return nil
+		}
+		got, err := describe(rel, path, info)
+		if err != nil {
+			return err
+		}
+		if got.Checksum != want.Checksum {
+			report.Modified = append(report.Modified, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range m.Files {
+		if !seen[file.Path] {
+			report.Missing = append(report.Missing, file.Path)
+		}
+	}
+	sort.Strings(report.Missing)
+	return report, nil
+}
+
+// ReadLocal reads the manifest from a local file.
+func ReadLocal(path string) (*Manifest, error) {
+	return fileio.ReadJSON[Manifest](path)
+}
+
+// walkFiles calls fn for each regular file in the package directory
+// except the package spec and the manifest, in lexical order.
+func walkFiles(dir string, fn func(rel, path string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == spec.FileName || rel == FileName {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(rel, path, info)
+	})
+}
+
+// describe calculates the file size and checksum.
+func describe(rel, path string, info fs.FileInfo) (File, error) {
+	checksum, err := fileio.CalcChecksum(path)
+	if err != nil {
+		return File{}, err
+	}
+	file := File{
+		Path:     rel,
+		Size:     info.Size(),
+		Checksum: "sha256-" + hex.EncodeToString(checksum),
+	}
+	return file, nil
+}
@@ -0,0 +1,122 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "sqlpkg.json", "{}")
+	writeFile(t, dir, "example.so", "example")
+	writeFile(t, dir, "dist/text.so", "text")
+
+	m, err := Build(dir)
+	if err != nil {
+		t.Fatalf("Build: unexpected error %v", err)
+	}
+	want := []File{
+		{
+			Path: "dist/text.so", Size: 4,
+			Checksum: "sha256-982d9e3eb996f559e633f4d194def3761d909f5a3b647d1a851fead67c32c9d1",
+		},
+		{
+			Path: "example.so", Size: 7,
+			Checksum: "sha256-50d858e0985ecc7f60418aaf0cc5ab587f42c2570a884095a9e8ccacd0f6545c",
+		},
+	}
+	if !reflect.DeepEqual(m.Files, want) {
+		t.Errorf("Build: unexpected files %+v", m.Files)
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "example.so", "example")
+
+	m, err := Build(dir)
+	if err != nil {
+		t.Fatalf("Build: unexpected error %v", err)
+	}
+	err = m.Save(dir)
+	if err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	got, err := ReadLocal(Path(dir))
+	if err != nil {
+		t.Fatalf("ReadLocal: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("ReadLocal: unexpected manifest %+v", got)
+	}
+
+	// the manifest does not include itself
+	m, err = Build(dir)
+	if err != nil {
+		t.Fatalf("Build: unexpected error %v", err)
+	}
+	if len(m.Files) != 1 {
+		t.Errorf("Build: unexpected files %+v", m.Files)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Run("intact", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "example.so", "example")
+		m, _ := Build(dir)
+		_ = m.Save(dir)
+
+		report, err := m.Verify(dir)
+		if err != nil {
+			t.Fatalf("Verify: unexpected error %v", err)
+		}
+		if !report.OK() {
+			t.Errorf("Verify: unexpected report %+v", report)
+		}
+	})
+	t.Run("changed", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "example.so", "example")
+		writeFile(t, dir, "resized.so", "resized")
+		writeFile(t, dir, "LICENSE", "license")
+		m, _ := Build(dir)
+
+		writeFile(t, dir, "example.so", "exampla")
+		writeFile(t, dir, "resized.so", "resized!")
+		writeFile(t, dir, "dist/extra.so", "extra")
+		os.Remove(filepath.Join(dir, "LICENSE"))
+
+		report, err := m.Verify(dir)
+		if err != nil {
+			t.Fatalf("Verify: unexpected error %v", err)
+		}
+		if report.OK() {
+			t.Fatal("Verify: expected changes")
+		}
+		if !reflect.DeepEqual(report.Modified, []string{"example.so", "resized.so"}) {
+			t.Errorf("Verify: unexpected modified files %v", report.Modified)
+		}
+		if !reflect.DeepEqual(report.Missing, []string{"LICENSE"}) {
+			t.Errorf("Verify: unexpected missing files %v", report.Missing)
+		}
+		if !reflect.DeepEqual(report.Extra, []string{"dist/extra.so"}) {
+			t.Errorf("Verify: unexpected extra files %v", report.Extra)
+		}
+	})
+}
+
+func writeFile(t *testing.T, dir, name, data string) {
+	path := filepath.Join(dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		t.Fatalf("MkdirAll: unexpected error %v", err)
+	}
+	err = os.WriteFile(path, []byte(data), 0644)
+	if err != nil {
+		t.Fatalf("WriteFile: unexpected error %v", err)
+	}
+}
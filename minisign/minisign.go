@@ -0,0 +1,174 @@
+// Package minisign verifies Ed25519 signatures in the minisign format.
+// See https://jedisct1.github.io/minisign/ for the format description.
+//
+// Supports both the legacy (Ed) and the prehashed (ED) signatures.
+// Does not support signing or encrypted secret keys.
+package minisign
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FileExt is the extension of signature files.
+const FileExt = ".minisig"
+
+const (
+	algoLegacy    = "Ed"
+	algoPrehashed = "ED"
+)
+
+const (
+	untrustedPrefix = "untrusted comment: "
+	trustedPrefix   = "trusted comment: "
+)
+
+var ErrInvalidKey = errors.New("invalid public key")
+var ErrInvalidSignature = errors.New("invalid signature")
+var ErrKeyMismatch = errors.New("signature key id does not match the public key")
+var ErrVerification = errors.New("signature verification failed")
+
+// A PublicKey is an Ed25519 public key with a minisign key id.
+type PublicKey struct {
+	KeyID [8]byte
+	Key   ed25519.PublicKey
+}
+
+// ParsePublicKey parses a base64-encoded minisign public key.
+// Accepts either the bare key or the full contents of the key file
+// (with the untrusted comment line).
+func ParsePublicKey(s string) (*PublicKey, error) {
+	line := lastLine(s)
+	data, err := base64.StdEncoding.DecodeString(line)
+	if err != nil || len(data) != 2+8+ed25519.PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+	if string(data[:2]) != algoLegacy {
+		return nil, ErrInvalidKey
+	}
+	key := &PublicKey{Key: ed25519.PublicKey(data[10:])}
+	copy(key.KeyID[:], data[2:10])
+	return key, nil
+}
+
+// ID returns the key id as a hex string (as displayed by minisign).
+func (k *PublicKey) ID() string {
+	// minisign displays the id as a little-endian number
+	id := k.KeyID
+	for i, j := 0, len(id)-1; i < j; i, j = i+1, j-1 {
+		id[i], id[j] = id[j], id[i]
+	}
+	return strings.ToUpper(hex.EncodeToString(id[:]))
+}
+
+// String returns the base64-encoded key.
+func (k *PublicKey) String() string {
+	data := make([]byte, 0, 2+8+ed25519.PublicKeySize)
+	data = append(data, algoLegacy...)
+	data = append(data, k.KeyID[:]...)
+	data = append(data, k.Key...)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// Verify checks the message signature.
+func (k *PublicKey) Verify(message []byte, sig *Signature) error {
+	if sig.KeyID != k.KeyID {
+		return ErrKeyMismatch
+	}
+
+	signed := message
+	if sig.Algorithm == algoPrehashed {
+		hash := blake2b512(message)
+		signed = hash[:]
+	}
+	if !ed25519.Verify(k.Key, signed, sig.Signature[:]) {
+		return ErrVerification
+	}
+
+	global := make([]byte, 0, len(sig.Signature)+len(sig.TrustedComment))
+	global = append(global, sig.Signature[:]...)
+	global = append(global, sig.TrustedComment...)
+	if !ed25519.Verify(k.Key, global, sig.GlobalSignature[:]) {
+		return fmt.Errorf("%w: invalid trusted comment", ErrVerification)
+	}
+	return nil
+}
+
+// A Signature is a minisign signature.
+type Signature struct {
+	Algorithm       string
+	KeyID           [8]byte
+	Signature       [ed25519.SignatureSize]byte
+	TrustedComment  string
+	GlobalSignature [ed25519.SignatureSize]byte
+}
+
+// ParseSignature parses the contents of a minisign signature file:
+//
+//	untrusted comment: <arbitrary text>
+//	base64(<signature_algorithm> || <key_id> || <signature>)
+//	trusted comment: <arbitrary text>
+//	base64(<global_signature>)
+func ParseSignature(data []byte) (*Signature, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if len(lines) != 4 {
+		return nil, ErrInvalidSignature
+	}
+	if !strings.HasPrefix(lines[0], untrustedPrefix) || !strings.HasPrefix(lines[2], trustedPrefix) {
+		return nil, ErrInvalidSignature
+	}
+
+	sigData, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sigData) != 2+8+ed25519.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+	algo := string(sigData[:2])
+	if algo != algoLegacy && algo != algoPrehashed {
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, algo)
+	}
+
+	globalData, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalData) != ed25519.SignatureSize {
+		return nil, ErrInvalidSignature
+	}
+
+	sig := &Signature{
+		Algorithm:      algo,
+		TrustedComment: strings.TrimPrefix(lines[2], trustedPrefix),
+	}
+	copy(sig.KeyID[:], sigData[2:10])
+	copy(sig.Signature[:], sigData[10:])
+	copy(sig.GlobalSignature[:], globalData)
+	return sig, nil
+}
+
+// Verify checks the message signature against any of the public keys.
+// Returns the key that verified the signature.
+func Verify(keys []*PublicKey, message, sigData []byte) (*PublicKey, error) {
+	sig, err := ParseSignature(sigData)
+	if err != nil {
+		return nil, err
+	}
+	var lastErr error = ErrKeyMismatch
+	for _, key := range keys {
+		err := key.Verify(message, sig)
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, ErrKeyMismatch) {
+			lastErr = err
+		}
+	}
+	return nil, lastErr
+}
+
+// lastLine returns the last non-empty line of the text.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,203 @@
+package minisign
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testSeed is the private key seed used to sign test data.
+var testSeed = []byte("sqlpkg-minisign-test-seed-000000")
+
+// testKeyID is the key id used to sign test data.
+var testKeyID = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+
+func TestBlake2b512(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+	}{
+		{[]byte{}, "786a02f742015903c6c6fd852552d272"},
+		{[]byte("abc"), "ba80a53f981c4d0d6a2797b69f12f6e9"},
+		{[]byte(strings.Repeat("a", 128)), "fc6c71f688f43ea7d60817478808f3ca"},
+		{[]byte(strings.Repeat("a", 129)), "55e6e0eb418149a8af92fd9ddc992547"},
+		{[]byte(strings.Repeat("a", 256)), "0eee13d0c73a2710c5015a8b4be0a161"},
+	}
+	for _, test := range tests {
+		sum := blake2b512(test.data)
+		got := hex.EncodeToString(sum[:16])
+		if got != test.want {
+			t.Errorf("blake2b512(%d bytes): expected %s, got %s", len(test.data), test.want, got)
+		}
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key := testKey()
+	t.Run("bare", func(t *testing.T) {
+		got, err := ParsePublicKey(key.String())
+		if err != nil {
+			t.Fatalf("ParsePublicKey: unexpected error %v", err)
+		}
+		if got.KeyID != key.KeyID || !got.Key.Equal(key.Key) {
+			t.Errorf("ParsePublicKey: unexpected key %v", got)
+		}
+	})
+	t.Run("file", func(t *testing.T) {
+		text := "untrusted comment: minisign public key " + key.ID() + "\n" + key.String() + "\n"
+		got, err := ParsePublicKey(text)
+		if err != nil {
+			t.Fatalf("ParsePublicKey: unexpected error %v", err)
+		}
+		if got.KeyID != key.KeyID {
+			t.Errorf("ParsePublicKey: unexpected key id %v", got.KeyID)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParsePublicKey("RWQinvalid")
+		if !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("ParsePublicKey: expected ErrInvalidKey, got %v", err)
+		}
+	})
+}
+
+func TestPublicKey_ID(t *testing.T) {
+	key := testKey()
+	if key.ID() != "0807060504030201" {
+		t.Errorf("ID: unexpected value %v", key.ID())
+	}
+}
+
+func TestPublicKey_Verify(t *testing.T) {
+	key := testKey()
+	message := []byte("hello world")
+	t.Run("prehashed", func(t *testing.T) {
+		sig, err := ParseSignature(testSign(message, algoPrehashed, "timestamp:1700000000"))
+		if err != nil {
+			t.Fatalf("ParseSignature: unexpected error %v", err)
+		}
+		err = key.Verify(message, sig)
+		if err != nil {
+			t.Errorf("Verify: unexpected error %v", err)
+		}
+	})
+	t.Run("legacy", func(t *testing.T) {
+		sig, err := ParseSignature(testSign(message, algoLegacy, "timestamp:1700000000"))
+		if err != nil {
+			t.Fatalf("ParseSignature: unexpected error %v", err)
+		}
+		err = key.Verify(message, sig)
+		if err != nil {
+			t.Errorf("Verify: unexpected error %v", err)
+		}
+	})
+	t.Run("tampered message", func(t *testing.T) {
+		sig, _ := ParseSignature(testSign(message, algoPrehashed, "timestamp:1700000000"))
+		err := key.Verify([]byte("hello world!"), sig)
+		if !errors.Is(err, ErrVerification) {
+			t.Errorf("Verify: expected ErrVerification, got %v", err)
+		}
+	})
+	t.Run("tampered comment", func(t *testing.T) {
+		sig, _ := ParseSignature(testSign(message, algoPrehashed, "timestamp:1700000000"))
+		sig.TrustedComment = "timestamp:1800000000"
+		err := key.Verify(message, sig)
+		if !errors.Is(err, ErrVerification) {
+			t.Errorf("Verify: expected ErrVerification, got %v", err)
+		}
+	})
+	t.Run("other key", func(t *testing.T) {
+		sig, _ := ParseSignature(testSign(message, algoPrehashed, "timestamp:1700000000"))
+		other := testKey()
+		other.KeyID = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+		err := other.Verify(message, sig)
+		if !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Verify: expected ErrKeyMismatch, got %v", err)
+		}
+	})
+}
+
+func TestParseSignature(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		data := testSign([]byte("hello"), algoPrehashed, "file:checksums.txt")
+		sig, err := ParseSignature(data)
+		if err != nil {
+			t.Fatalf("ParseSignature: unexpected error %v", err)
+		}
+		if sig.Algorithm != algoPrehashed {
+			t.Errorf("ParseSignature: unexpected algorithm %v", sig.Algorithm)
+		}
+		if sig.KeyID != testKeyID {
+			t.Errorf("ParseSignature: unexpected key id %v", sig.KeyID)
+		}
+		if sig.TrustedComment != "file:checksums.txt" {
+			t.Errorf("ParseSignature: unexpected trusted comment %v", sig.TrustedComment)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseSignature([]byte("untrusted comment: hello\nworld"))
+		if !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("ParseSignature: expected ErrInvalidSignature, got %v", err)
+		}
+	})
+}
+
+func TestVerify(t *testing.T) {
+	key := testKey()
+	other := testKey()
+	other.KeyID = [8]byte{8, 7, 6, 5, 4, 3, 2, 1}
+	message := []byte("hello world")
+	sigData := testSign(message, algoPrehashed, "timestamp:1700000000")
+	t.Run("match", func(t *testing.T) {
+		got, err := Verify([]*PublicKey{other, key}, message, sigData)
+		if err != nil {
+			t.Fatalf("Verify: unexpected error %v", err)
+		}
+		if got != key {
+			t.Errorf("Verify: unexpected key %v", got.ID())
+		}
+	})
+	t.Run("no match", func(t *testing.T) {
+		_, err := Verify([]*PublicKey{other}, message, sigData)
+		if !errors.Is(err, ErrKeyMismatch) {
+			t.Errorf("Verify: expected ErrKeyMismatch, got %v", err)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Verify([]*PublicKey{key}, []byte("hello"), sigData)
+		if !errors.Is(err, ErrVerification) {
+			t.Errorf("Verify: expected ErrVerification, got %v", err)
+		}
+	})
+}
+
+// testKey returns the public key used to sign test data.
+func testKey() *PublicKey {
+	priv := ed25519.NewKeyFromSeed(testSeed)
+	return &PublicKey{KeyID: testKeyID, Key: priv.Public().(ed25519.PublicKey)}
+}
+
+// testSign signs the message the same way minisign does.
+func testSign(message []byte, algo, comment string) []byte {
+	priv := ed25519.NewKeyFromSeed(testSeed)
+	signed := message
+	if algo == algoPrehashed {
+		hash := blake2b512(message)
+		signed = hash[:]
+	}
+	sig := ed25519.Sign(priv, signed)
+	global := ed25519.Sign(priv, append(append([]byte{}, sig...), comment...))
+
+	sigData := append([]byte(algo), testKeyID[:]...)
+	sigData = append(sigData, sig...)
+	lines := []string{
+		untrustedPrefix + "signature from minisign secret key",
+		base64.StdEncoding.EncodeToString(sigData),
+		trustedPrefix + comment,
+		base64.StdEncoding.EncodeToString(global),
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"sqlpkg.org/cli/httpx"
+)
+
+// Gitea works with Gitea or Forgejo repositories (e.g. codeberg.org).
+// WebURL is the base url of the repositories (e.g. https://codeberg.org),
+// APIURL is the base url of the REST API (e.g. https://codeberg.org/api/v1).
+type Gitea struct {
+	WebURL string
+	APIURL string
+}
+
+// LatestRelease fetches the latest release tag for the repository.
+func (g *Gitea) LatestRelease(ctx context.Context, client httpx.Client, owner, repo string) (string, error) {
+	if err := checkOwner(owner); err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", g.APIURL, owner, repo)
+	rel, err := httpx.GetJSON[release](ctx, client, url)
+	if err != nil {
+		return "", err
+	}
+	return rel.TagName, nil
+}
+
+// ListReleases fetches the release tags for the repository, newest first.
+func (g *Gitea) ListReleases(ctx context.Context, client httpx.Client, owner, repo string) ([]string, error) {
+	if err := checkOwner(owner); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", g.APIURL, owner, repo)
+	releases, err := httpx.GetJSON[[]release](ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	return releaseTags(*releases), nil
+}
+
+// DownloadBase returns the template of the release assets url.
+func (g *Gitea) DownloadBase() string {
+	return "{repository}/releases/download/{version}"
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// releasesServer serves GitHub-like release endpoints
+// (GitHub, GitHub Enterprise and Gitea have the same API)
+// under the given API path.
+func releasesServer(apiPath string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case apiPath + "/repos/nalgeon/sqlean/releases/latest":
+			_, _ = w.Write([]byte(`{"tag_name":"0.27.1"}`))
+		case apiPath + "/repos/nalgeon/sqlean/releases":
+			_, _ = w.Write([]byte(`[{"tag_name":"0.27.1"},{"tag_name":"0.27.0"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestGitea(t *testing.T) {
+	srv := releasesServer("/api/v1")
+	defer srv.Close()
+	testReleases(t, srv, &Gitea{WebURL: srv.URL, APIURL: srv.URL + "/api/v1"})
+}
+
+func TestGitHubEnterprise(t *testing.T) {
+	srv := releasesServer("/api/v3")
+	defer srv.Close()
+	prov, err := New(Config{Type: TypeGitHub, APIURL: srv.URL + "/api/v3"})
+	if err != nil {
+		t.Fatalf("New: unexpected error %v", err)
+	}
+	testReleases(t, srv, prov)
+}
+
+// testReleases checks the provider against the releases server.
+func testReleases(t *testing.T, srv *httptest.Server, prov Provider) {
+	ctx := context.Background()
+	t.Run("latest", func(t *testing.T) {
+		tag, err := prov.LatestRelease(ctx, srv.Client(), "nalgeon", "sqlean")
+		if err != nil {
+			t.Fatalf("LatestRelease: unexpected error %v", err)
+		}
+		if tag != "0.27.1" {
+			t.Errorf("LatestRelease: unexpected tag %v", tag)
+		}
+	})
+	t.Run("list", func(t *testing.T) {
+		tags, err := prov.ListReleases(ctx, srv.Client(), "nalgeon", "sqlean")
+		if err != nil {
+			t.Fatalf("ListReleases: unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(tags, []string{"0.27.1", "0.27.0"}) {
+			t.Errorf("ListReleases: unexpected tags %v", tags)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := prov.LatestRelease(ctx, srv.Client(), "nalgeon", "unknown")
+		if err == nil {
+			t.Fatal("LatestRelease: expected error, got nil")
+		}
+	})
+	t.Run("nested owner", func(t *testing.T) {
+		_, err := prov.LatestRelease(ctx, srv.Client(), "nalgeon/sqlite", "sqlean")
+		if err == nil {
+			t.Fatal("LatestRelease: expected error, got nil")
+		}
+	})
+	t.Run("download base", func(t *testing.T) {
+		want := "{repository}/releases/download/{version}"
+		if got := prov.DownloadBase(); got != want {
+			t.Errorf("DownloadBase: unexpected value %v", got)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"sqlpkg.org/cli/httpx"
+)
+
+// GitHubCom is the public GitHub.
+var GitHubCom = &GitHub{WebURL: "https://github.com", APIURL: "https://api.github.com"}
+
+// ErrAssetNotFound means the release does not have the asset.
+var ErrAssetNotFound = errors.New("release asset is not found")
+
+// GitHub works with github.com or GitHub Enterprise repositories.
+// WebURL is the base url of the repositories (e.g. https://github.com),
+// APIURL is the base url of the REST API (e.g. https://api.github.com).
+type GitHub struct {
+	WebURL string
+	APIURL string
+}
+
+// githubRelease is a GitHub repository release.
+type githubRelease struct {
+	TagName string        `json:"tag_name"`
+	Assets  []githubAsset `json:"assets"`
+}
+
+// githubAsset is a GitHub release asset.
+type githubAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// LatestRelease fetches the latest release tag for the repository.
+// If the API rate limit is exceeded, resolves the tag
+// through the /releases/latest page redirect instead.
+func (g *GitHub) LatestRelease(ctx context.Context, client httpx.Client, owner, repo string) (string, error) {
+	if err := checkOwner(owner); err != nil {
+		return "", err
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", g.APIURL, owner, repo)
+	rel, err := httpx.GetJSON[release](ctx, client, url)
+	var rateErr *httpx.RateLimitError
+	if errors.As(err, &rateErr) {
+		tag, fallbackErr := g.latestFromPage(ctx, client, owner, repo)
+		if fallbackErr != nil {
+			return "", fmt.Errorf("github api %w (set GITHUB_TOKEN to raise the limit)", err)
+		}
+		return tag, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return rel.TagName, nil
+}
+
+// ListReleases fetches the release tags for the repository, newest first.
+func (g *GitHub) ListReleases(ctx context.Context, client httpx.Client, owner, repo string) ([]string, error) {
+	if err := checkOwner(owner); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", g.APIURL, owner, repo)
+	releases, err := httpx.GetJSON[[]release](ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	return releaseTags(*releases), nil
+}
+
+// DownloadBase returns the template of the release assets url.
+func (g *GitHub) DownloadBase() string {
+	return "{repository}/releases/download/{version}"
+}
+
+// latestFromPage resolves the latest release tag through
+// the /releases/latest page, which redirects to /releases/tag/<tag>.
+// Unlike the API, the page is not rate-limited.
+func (g *GitHub) latestFromPage(ctx context.Context, client httpx.Client, owner, repo string) (string, error) {
+	url := fmt.Sprintf("%s/%s/%s/releases/latest", g.WebURL, owner, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Request == nil {
+		return "", fmt.Errorf("got http status %d", resp.StatusCode)
+	}
+
+	// the client follows the redirect, so the final request
+	// points to the release page
+	prefix := fmt.Sprintf("/%s/%s/releases/tag/", owner, repo)
+	path := resp.Request.URL.Path
+	if !strings.HasPrefix(path, prefix) {
+		return "", fmt.Errorf("unexpected release page: %s", path)
+	}
+	return strings.TrimPrefix(path, prefix), nil
+}
+
+// IsReleaseAsset checks if the url points to a release asset
+// (e.g. https://github.com/nalgeon/sqlean/releases/download/0.21.6/sqlean.zip).
+func (g *GitHub) IsReleaseAsset(assetUrl string) bool {
+	_, _, _, _, err := g.parseAssetUrl(assetUrl)
+	return err == nil
+}
+
+// AssetURL returns the API url of the release asset. Unlike the public
+// download url, the API url works for private repositories too
+// (requires the Accept: application/octet-stream header).
+func (g *GitHub) AssetURL(ctx context.Context, client httpx.Client, assetUrl string) (string, error) {
+	owner, repo, tag, name, err := g.parseAssetUrl(assetUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid release asset url: %s", assetUrl)
+	}
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", g.APIURL, owner, repo, tag)
+	rel, err := httpx.GetJSON[githubRelease](ctx, client, url)
+	if err != nil {
+		return "", err
+	}
+	for _, asset := range rel.Assets {
+		if asset.Name == name {
+			return asset.URL, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+}
+
+// parseAssetUrl extracts owner, repo, release tag and asset name
+// from the release asset url.
+func (g *GitHub) parseAssetUrl(assetUrl string) (owner, repo, tag, name string, err error) {
+	u, err := url.Parse(assetUrl)
+	if err != nil || u.Host != httpx.Hostname(g.WebURL) {
+		return "", "", "", "", errors.New(assetUrl)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 6 || parts[2] != "releases" || parts[3] != "download" {
+		return "", "", "", "", errors.New(assetUrl)
+	}
+	return parts[0], parts[1], parts[4], parts[5], nil
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/httpx"
+)
+
+func TestLatestRelease(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		client := httpx.Mock("valid")
+		tag, err := GitHubCom.LatestRelease(context.Background(), client, "nalgeon", "sqlean")
+		if err != nil {
+			t.Fatalf("LatestRelease: unexpected error %v", err)
+		}
+		if tag != "0.21.6" {
+			t.Errorf("LatestRelease: unexpected tag %v", tag)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		client := httpx.Mock()
+		_, err := GitHubCom.LatestRelease(context.Background(), client, "nalgeon", "sqlean")
+		if err == nil {
+			t.Fatal("LatestRelease: expected error, got nil")
+		}
+	})
+}
+
+func TestLatestRelease_rateLimit(t *testing.T) {
+	pageOK := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/nalgeon/sqlean/releases/latest":
+			w.Header().Set("X-RateLimit-Limit", "60")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "1767225600")
+			http.Error(w, "rate limit exceeded", http.StatusForbidden)
+		case "/nalgeon/sqlean/releases/latest":
+			if !pageOK {
+				http.NotFound(w, r)
+				return
+			}
+			http.Redirect(w, r, "/nalgeon/sqlean/releases/tag/0.27.1", http.StatusFound)
+		case "/nalgeon/sqlean/releases/tag/0.27.1":
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	gh := &GitHub{WebURL: srv.URL, APIURL: srv.URL}
+
+	t.Run("fallback", func(t *testing.T) {
+		tag, err := gh.LatestRelease(context.Background(), srv.Client(), "nalgeon", "sqlean")
+		if err != nil {
+			t.Fatalf("LatestRelease: unexpected error %v", err)
+		}
+		if tag != "0.27.1" {
+			t.Errorf("LatestRelease: unexpected tag %v", tag)
+		}
+	})
+	t.Run("fallback failed", func(t *testing.T) {
+		pageOK = false
+		_, err := gh.LatestRelease(context.Background(), srv.Client(), "nalgeon", "sqlean")
+		var rateErr *httpx.RateLimitError
+		if !errors.As(err, &rateErr) {
+			t.Fatalf("LatestRelease: unexpected error %v", err)
+		}
+		if !strings.Contains(err.Error(), "GITHUB_TOKEN") {
+			t.Errorf("LatestRelease: unexpected message %q", err.Error())
+		}
+	})
+}
+
+func TestIsReleaseAsset(t *testing.T) {
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://github.com/nalgeon/sqlean/releases/download/0.21.6/sqlean-linux-x86.zip", true},
+		{"https://github.com/nalgeon/sqlean/raw/main/sqlpkg.json", false},
+		{"https://example.org/nalgeon/sqlean/releases/download/0.21.6/sqlean-linux-x86.zip", false},
+		{"https://github.com/nalgeon/sqlean", false},
+	}
+	for _, test := range tests {
+		ok := GitHubCom.IsReleaseAsset(test.url)
+		if ok != test.ok {
+			t.Errorf("IsReleaseAsset(%s): expected %v, got %v", test.url, test.ok, ok)
+		}
+	}
+}
+
+func TestAssetURL(t *testing.T) {
+	ctx := context.Background()
+	client := httpx.Mock("release")
+	t.Run("found", func(t *testing.T) {
+		url := "https://github.com/nalgeon/sqlean/releases/download/0.21.6/sqlean-macos-arm64.zip"
+		got, err := GitHubCom.AssetURL(ctx, client, url)
+		if err != nil {
+			t.Fatalf("AssetURL: unexpected error %v", err)
+		}
+		want := "https://api.github.com/repos/nalgeon/sqlean/releases/assets/102"
+		if got != want {
+			t.Errorf("AssetURL: expected %s, got %s", want, got)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		url := "https://github.com/nalgeon/sqlean/releases/download/0.21.6/sqlean-win-x64.zip"
+		_, err := GitHubCom.AssetURL(ctx, client, url)
+		if !errors.Is(err, ErrAssetNotFound) {
+			t.Fatalf("AssetURL: unexpected error %v", err)
+		}
+	})
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := GitHubCom.AssetURL(ctx, client, "https://github.com/nalgeon/sqlean")
+		if err == nil {
+			t.Fatal("AssetURL: expected error, got nil")
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"sqlpkg.org/cli/httpx"
+)
+
+// GitLab works with gitlab.com or self-hosted GitLab repositories.
+// WebURL is the base url of the repositories (e.g. https://gitlab.com),
+// APIURL is the base url of the REST API (e.g. https://gitlab.com/api/v4).
+type GitLab struct {
+	WebURL string
+	APIURL string
+}
+
+// LatestRelease fetches the latest release tag for the repository.
+func (g *GitLab) LatestRelease(ctx context.Context, client httpx.Client, owner, repo string) (string, error) {
+	tags, err := g.ListReleases(ctx, client, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	if len(tags) == 0 {
+		return "", errors.New("no releases found")
+	}
+	return tags[0], nil
+}
+
+// ListReleases fetches the release tags for the repository, newest first.
+func (g *GitLab) ListReleases(ctx context.Context, client httpx.Client, owner, repo string) ([]string, error) {
+	// the project id is the url-encoded project path
+	id := url.PathEscape(owner + "/" + repo)
+	url := fmt.Sprintf("%s/projects/%s/releases?order_by=released_at&sort=desc", g.APIURL, id)
+	releases, err := httpx.GetJSON[[]release](ctx, client, url)
+	if err != nil {
+		return nil, err
+	}
+	return releaseTags(*releases), nil
+}
+
+// DownloadBase returns the template of the release assets url.
+// Uses release asset permalinks, so asset links should have
+// the file path set to the asset file name.
+func (g *GitLab) DownloadBase() string {
+	return "{repository}/-/releases/{version}/downloads"
+}
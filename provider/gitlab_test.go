@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGitLab(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/nalgeon%2Fsqlean/releases":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"tag_name":"0.27.1"},{"tag_name":"0.27.0"}]`))
+		case "/api/v4/projects/nalgeon%2Fsqlite%2Fsqlean/releases":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`[{"tag_name":"0.28.0"}]`))
+		case "/api/v4/projects/nalgeon%2Fempty/releases":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	gl := &GitLab{WebURL: srv.URL, APIURL: srv.URL + "/api/v4"}
+
+	t.Run("latest", func(t *testing.T) {
+		tag, err := gl.LatestRelease(ctx, srv.Client(), "nalgeon", "sqlean")
+		if err != nil {
+			t.Fatalf("LatestRelease: unexpected error %v", err)
+		}
+		if tag != "0.27.1" {
+			t.Errorf("LatestRelease: unexpected tag %v", tag)
+		}
+	})
+	t.Run("subgroup", func(t *testing.T) {
+		tag, err := gl.LatestRelease(ctx, srv.Client(), "nalgeon/sqlite", "sqlean")
+		if err != nil {
+			t.Fatalf("LatestRelease: unexpected error %v", err)
+		}
+		if tag != "0.28.0" {
+			t.Errorf("LatestRelease: unexpected tag %v", tag)
+		}
+	})
+	t.Run("list", func(t *testing.T) {
+		tags, err := gl.ListReleases(ctx, srv.Client(), "nalgeon", "sqlean")
+		if err != nil {
+			t.Fatalf("ListReleases: unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(tags, []string{"0.27.1", "0.27.0"}) {
+			t.Errorf("ListReleases: unexpected tags %v", tags)
+		}
+	})
+	t.Run("no releases", func(t *testing.T) {
+		_, err := gl.LatestRelease(ctx, srv.Client(), "nalgeon", "empty")
+		if err == nil {
+			t.Fatal("LatestRelease: expected error, got nil")
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := gl.LatestRelease(ctx, srv.Client(), "nalgeon", "unknown")
+		if err == nil {
+			t.Fatal("LatestRelease: expected error, got nil")
+		}
+	})
+	t.Run("download base", func(t *testing.T) {
+		want := "{repository}/-/releases/{version}/downloads"
+		if got := gl.DownloadBase(); got != want {
+			t.Errorf("DownloadBase: unexpected value %v", got)
+		}
+	})
+}
@@ -0,0 +1,144 @@
+// Package provider works with code hosting providers
+// (GitHub, GitLab, Gitea and their self-hosted instances).
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"sqlpkg.org/cli/httpx"
+)
+
+// A Provider is a code hosting service that publishes repository releases.
+type Provider interface {
+	// LatestRelease returns the latest release tag of the repository.
+	LatestRelease(ctx context.Context, client httpx.Client, owner, repo string) (string, error)
+	// ListReleases returns the release tags of the repository, newest first.
+	ListReleases(ctx context.Context, client httpx.Client, owner, repo string) ([]string, error)
+	// DownloadBase returns the template of the release assets url
+	// with {repository} and {version} placeholders.
+	DownloadBase() string
+}
+
+// Provider types.
+const (
+	TypeGitHub = "github"
+	TypeGitLab = "gitlab"
+	TypeGitea  = "gitea"
+)
+
+// A Config describes a self-hosted provider instance (e.g. GitHub Enterprise).
+// Type is one of github, gitlab or gitea (which includes Forgejo).
+// APIURL is the url of the provider's API (e.g. https://ghe.example.org/api/v3).
+// Host is the host name of the repositories; defaults to the APIURL host.
+type Config struct {
+	Type   string `json:"type"`
+	APIURL string `json:"api_url"`
+	Host   string `json:"host,omitempty"`
+}
+
+// known are providers of public hosts.
+var known = map[string]Provider{
+	"github.com":   GitHubCom,
+	"gitlab.com":   &GitLab{WebURL: "https://gitlab.com", APIURL: "https://gitlab.com/api/v4"},
+	"codeberg.org": &Gitea{WebURL: "https://codeberg.org", APIURL: "https://codeberg.org/api/v1"},
+}
+
+// ErrUnknownType means the provider type is not supported.
+var ErrUnknownType = errors.New("unknown provider type")
+
+// New creates a provider from the config.
+func New(cfg Config) (Provider, error) {
+	u, err := url.Parse(cfg.APIURL)
+	if err != nil || !httpx.IsURL(cfg.APIURL) {
+		return nil, fmt.Errorf("invalid provider api url: %s", cfg.APIURL)
+	}
+	apiUrl := strings.TrimSuffix(cfg.APIURL, "/")
+	webUrl := u.Scheme + "://" + cfg.host()
+	switch cfg.Type {
+	case TypeGitHub:
+		return &GitHub{WebURL: webUrl, APIURL: apiUrl}, nil
+	case TypeGitLab:
+		return &GitLab{WebURL: webUrl, APIURL: apiUrl}, nil
+	case TypeGitea:
+		return &Gitea{WebURL: webUrl, APIURL: apiUrl}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, cfg.Type)
+	}
+}
+
+// host returns the host name of the provider's repositories.
+func (c Config) host() string {
+	if c.Host != "" {
+		return c.Host
+	}
+	u, err := url.Parse(c.APIURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// ForRepo returns the provider of the repository url. Configured providers
+// take precedence over the known ones (github.com, gitlab.com and codeberg.org).
+// Returns nil if the provider is unknown.
+func ForRepo(repoUrl string, configs []Config) (Provider, error) {
+	u, err := url.Parse(repoUrl)
+	if err != nil || u.Host == "" {
+		return nil, nil
+	}
+	for _, cfg := range configs {
+		if cfg.host() == u.Host {
+			return New(cfg)
+		}
+	}
+	return known[u.Host], nil
+}
+
+// ParseRepoUrl extracts owner and repo names from the repo url.
+// The repo is the last path segment, and the owner is everything
+// before it, so nested GitLab groups (group/subgroup/project)
+// have the owner set to group/subgroup.
+func ParseRepoUrl(repoUrl string) (owner string, repo string, err error) {
+	u, err := url.Parse(repoUrl)
+	if err != nil {
+		err = errors.New(repoUrl)
+		return
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || slices.Contains(parts, "") {
+		err = errors.New(repoUrl)
+		return
+	}
+	owner = strings.Join(parts[:len(parts)-1], "/")
+	repo = parts[len(parts)-1]
+	return
+}
+
+// checkOwner checks that the owner is a single user or organization name.
+// Providers other than GitLab do not support nested owners.
+func checkOwner(owner string) error {
+	if strings.Contains(owner, "/") {
+		return fmt.Errorf("nested repository owner is not supported: %s", owner)
+	}
+	return nil
+}
+
+// release is a repository release
+// (GitHub, GitLab and Gitea use the same field name).
+type release struct {
+	TagName string `json:"tag_name"`
+}
+
+// releaseTags returns the tags of the releases.
+func releaseTags(releases []release) []string {
+	tags := make([]string, len(releases))
+	for i, rel := range releases {
+		tags[i] = rel.TagName
+	}
+	return tags
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want Provider
+	}{
+		{
+			"github enterprise",
+			Config{Type: TypeGitHub, APIURL: "https://ghe.example.org/api/v3/"},
+			&GitHub{WebURL: "https://ghe.example.org", APIURL: "https://ghe.example.org/api/v3"},
+		},
+		{
+			"gitlab",
+			Config{Type: TypeGitLab, APIURL: "https://git.example.org/api/v4"},
+			&GitLab{WebURL: "https://git.example.org", APIURL: "https://git.example.org/api/v4"},
+		},
+		{
+			"gitea with host",
+			Config{Type: TypeGitea, APIURL: "https://api.example.org/v1", Host: "git.example.org"},
+			&Gitea{WebURL: "https://git.example.org", APIURL: "https://api.example.org/v1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := New(test.cfg)
+			if err != nil {
+				t.Fatalf("New: unexpected error %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("New: expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := New(Config{Type: "svn", APIURL: "https://svn.example.org"})
+		if !errors.Is(err, ErrUnknownType) {
+			t.Fatalf("New: unexpected error %v", err)
+		}
+	})
+	t.Run("invalid api url", func(t *testing.T) {
+		_, err := New(Config{Type: TypeGitHub, APIURL: "ghe.example.org"})
+		if err == nil {
+			t.Fatal("New: expected error, got nil")
+		}
+	})
+}
+
+func TestForRepo(t *testing.T) {
+	configs := []Config{
+		{Type: TypeGitHub, APIURL: "https://ghe.example.org/api/v3"},
+		{Type: TypeGitea, APIURL: "https://codeberg.org/api/v2"},
+	}
+	tests := []struct {
+		url  string
+		want Provider
+	}{
+		{"https://github.com/nalgeon/sqlean", GitHubCom},
+		{"https://gitlab.com/nalgeon/sqlean", known["gitlab.com"]},
+		{"https://ghe.example.org/nalgeon/sqlean", &GitHub{WebURL: "https://ghe.example.org", APIURL: "https://ghe.example.org/api/v3"}},
+		{"https://codeberg.org/nalgeon/sqlean", &Gitea{WebURL: "https://codeberg.org", APIURL: "https://codeberg.org/api/v2"}},
+		{"https://antonz.org/sqlean", nil},
+		{"sqlean", nil},
+	}
+	for _, test := range tests {
+		got, err := ForRepo(test.url, configs)
+		if err != nil {
+			t.Errorf("ForRepo(%s): unexpected error %v", test.url, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ForRepo(%s): expected %+v, got %+v", test.url, test.want, got)
+		}
+	}
+}
+
+func TestParseRepoUrl(t *testing.T) {
+	type test struct {
+		url         string
+		owner, repo string
+	}
+	valid := []test{
+		{"https://github.com/nalgeon/sqlean", "nalgeon", "sqlean"},
+		{"https://github.com/nalgeon/sqlean/", "nalgeon", "sqlean"},
+		{"https://github.com/asg017/sqlite-vss", "asg017", "sqlite-vss"},
+		{"https://gitlab.com/group/subgroup/project", "group/subgroup", "project"},
+	}
+	for _, test := range valid {
+		owner, repo, err := ParseRepoUrl(test.url)
+		if err != nil {
+			t.Errorf("ParseRepoUrl(%s): unexpected error %v", test.url, err)
+			continue
+		}
+		if owner != test.owner {
+			t.Errorf("ParseRepoUrl(%s): unexpected owner %v", test.url, test.owner)
+		}
+		if repo != test.repo {
+			t.Errorf("ParseRepoUrl(%s): unexpected name %v", test.url, test.repo)
+		}
+	}
+
+	invalid := []string{
+		"https://github.com/nalgeon",
+		"https://antonz.org",
+		"https://gitlab.com/group//project",
+	}
+	for _, url := range invalid {
+		_, _, err := ParseRepoUrl(url)
+		if err == nil {
+			t.Errorf("ParseRepoUrl(%s): expected error, got nil", url)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"context"
 	"path/filepath"
 	"strings"
 
@@ -15,9 +16,9 @@ type AssetPath struct {
 }
 
 // Exists checks if the asset actually exists at the said path.
-func (p *AssetPath) Exists() bool {
+func (p *AssetPath) Exists(ctx context.Context, client httpx.Client) bool {
 	if p.IsRemote {
-		return httpx.Exists(p.Value)
+		return httpx.Exists(ctx, client, p.Value)
 	} else {
 		return fileio.Exists(p.Value)
 	}
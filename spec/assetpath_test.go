@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"context"
 	"path"
 	"path/filepath"
 	"testing"
@@ -9,11 +10,11 @@ import (
 func TestAssetPath_Exists(t *testing.T) {
 	t.Run("local", func(t *testing.T) {
 		p := &AssetPath{Value: filepath.Join("testdata", "sqlpkg.json"), IsRemote: false}
-		if !p.Exists() {
+		if !p.Exists(context.Background(), nil) {
 			t.Errorf("Exists: expected true for %v", p.Value)
 		}
 		p = &AssetPath{Value: filepath.Join("testdata", "null.json"), IsRemote: false}
-		if p.Exists() {
+		if p.Exists(context.Background(), nil) {
 			t.Errorf("Exists: expected false for %v", p.Value)
 		}
 	})
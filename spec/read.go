@@ -1,9 +1,11 @@
 package spec
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
 	"sqlpkg.org/cli/fileio"
 	"sqlpkg.org/cli/httpx"
@@ -21,38 +23,50 @@ var reOwnerName = regexp.MustCompile(`^[\w\-_.]+/[\w\-_.]+$`)
 //   - github repo: github.com/nalgeon/sqlean
 //   - custom url: https://antonz.org/stuff/whatever/sqlean.json
 //   - local path: /Users/anton/Desktop/sqlean.json
-func Read(path string) (pkg *Package, err error) {
+//
+// Owner-name pairs are looked up in the registries, in order.
+// If a registry answers, its name is stored in the package's Registry.
+func Read(ctx context.Context, client httpx.Client, path string, registries []Registry) (pkg *Package, err error) {
 	errs := []error{}
-	paths := expandPath(path)
-	for _, path := range paths {
-		readFunc := inferReader(path)
-		pkg, err = readFunc(path)
+	locs := expandPath(path, registries)
+	for _, loc := range locs {
+		readFunc := inferReader(ctx, client, loc.path)
+		pkg, err = readFunc(loc.path)
 		if err == nil {
-			pkg.Specfile = path
+			pkg.Specfile = loc.path
+			pkg.Registry = loc.registry
 			return pkg, nil
 		} else {
-			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			errs = append(errs, fmt.Errorf("%s: %w", loc.path, err))
 		}
 	}
 	return pkg, errors.Join(errs...)
 }
 
+// A location is a possible path to the package spec file.
+// Registry is the name of the registry the path belongs to (if any).
+type location struct {
+	path     string
+	registry string
+}
+
 // expandPath generates possible paths to the package spec file.
-func expandPath(path string) []string {
+func expandPath(path string, registries []Registry) []location {
 	if reGithub.MatchString(path) {
 		// try reading from the main branch of the github repository
-		return []string{fmt.Sprintf("https://%s/raw/main/%s", path, FileName)}
+		return []location{{path: fmt.Sprintf("https://%s/raw/main/%s", path, FileName)}}
 	}
 	if reOwnerName.MatchString(path) {
-		// can be a local path or an owner-name pair, which in turn can point
-		// to the author's repo or to the sqlpkg's registry
-		return []string{
-			path,
-			fmt.Sprintf("https://github.com/%s/raw/main/%s", path, FileName),
-			fmt.Sprintf("https://github.com/nalgeon/sqlpkg/raw/main/pkg/%s.json", path),
+		// can be a local path or an owner-name pair,
+		// which in turn can point to any of the registries
+		owner, name, _ := strings.Cut(path, "/")
+		locs := []location{{path: path}}
+		for _, reg := range registries {
+			locs = append(locs, location{reg.SpecPath(owner, name), reg.Name})
 		}
+		return locs
 	}
-	return []string{path}
+	return []location{{path: path}}
 }
 
 // ReadLocal reads package spec from a local file.
@@ -61,8 +75,8 @@ func ReadLocal(path string) (pkg *Package, err error) {
 }
 
 // ReadRemote reads package spec from a remote url.
-func ReadRemote(path string) (pkg *Package, err error) {
-	return httpx.GetJSON[Package](path)
+func ReadRemote(ctx context.Context, client httpx.Client, path string) (pkg *Package, err error) {
+	return httpx.GetJSON[Package](ctx, client, path)
 }
 
 // A ReadFunc if a function that reads package spec from a given path.
@@ -70,9 +84,11 @@ type ReadFunc func(path string) (*Package, error)
 
 // inferReader returns a proper reader function for a path,
 // which can be a local file path or a remote url path.
-func inferReader(path string) ReadFunc {
+func inferReader(ctx context.Context, client httpx.Client, path string) ReadFunc {
 	if httpx.IsURL(path) {
-		return ReadRemote
+		return func(path string) (*Package, error) {
+			return ReadRemote(ctx, client, path)
+		}
 	} else {
 		return ReadLocal
 	}
@@ -1,6 +1,7 @@
 package spec
 
 import (
+	"context"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -12,7 +13,7 @@ import (
 func TestRead(t *testing.T) {
 	t.Run("valid", func(t *testing.T) {
 		path := filepath.Join("testdata", "sqlpkg.json")
-		got, err := Read(path)
+		got, err := Read(context.Background(), nil, path, nil)
 		if err != nil {
 			t.Fatalf("Read: unexpected error %v", err)
 		}
@@ -42,7 +43,7 @@ func TestRead(t *testing.T) {
 	})
 	t.Run("missing", func(t *testing.T) {
 		path := filepath.Join("testdata", "missing", "sqlpkg.json")
-		_, err := Read(path)
+		_, err := Read(context.Background(), nil, path, nil)
 		if err == nil {
 			t.Fatal("Read: expected error, got nil")
 		}
@@ -53,10 +54,10 @@ func TestRead(t *testing.T) {
 }
 
 func TestReadRemote(t *testing.T) {
-	httpx.Mock()
+	client := httpx.Mock()
 	t.Run("valid", func(t *testing.T) {
 		url := "https://antonz.org/sqlpkg.json"
-		got, err := ReadRemote(url)
+		got, err := ReadRemote(context.Background(), client, url)
 		if err != nil {
 			t.Fatalf("ReadRemote: unexpected error %v", err)
 		}
@@ -85,7 +86,7 @@ func TestReadRemote(t *testing.T) {
 	})
 	t.Run("missing", func(t *testing.T) {
 		url := "https://github.com/nalgeon/sqlite-example/blob/main/missing.json"
-		_, err := ReadRemote(url)
+		_, err := ReadRemote(context.Background(), client, url)
 		if err == nil {
 			t.Fatal("ReadRemote: expected error, got nil")
 		}
@@ -96,29 +97,33 @@ func TestReadRemote(t *testing.T) {
 }
 
 func Test_expandPath(t *testing.T) {
+	registries := []Registry{
+		{Name: "internal", URL: "https://example.org/{owner}/{name}.json"},
+		{Name: "local", Dir: "/opt/registry"},
+	}
 	tests := []struct {
 		name, path string
-		want       []string
+		want       []location
 	}{
-		{"local", "./testdata/sqlpkg.json", []string{"./testdata/sqlpkg.json"}},
+		{"local", "./testdata/sqlpkg.json", []location{{path: "./testdata/sqlpkg.json"}}},
 		{
 			"github",
 			"github.com/nalgeon/example",
-			[]string{"https://github.com/nalgeon/example/raw/main/sqlpkg.json"},
+			[]location{{path: "https://github.com/nalgeon/example/raw/main/sqlpkg.json"}},
 		},
 		{
 			"owner-name",
 			"nalgeon/example",
-			[]string{
-				"nalgeon/example",
-				"https://github.com/nalgeon/example/raw/main/sqlpkg.json",
-				"https://github.com/nalgeon/sqlpkg/raw/main/pkg/nalgeon/example.json",
+			[]location{
+				{path: "nalgeon/example"},
+				{"https://example.org/nalgeon/example.json", "internal"},
+				{filepath.Join("/opt/registry", "nalgeon", "example.json"), "local"},
 			},
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := expandPath(test.path)
+			got := expandPath(test.path, registries)
 			if !reflect.DeepEqual(got, test.want) {
 				t.Errorf("expandPath: unexpected value %v", got)
 			}
@@ -126,19 +131,43 @@ func Test_expandPath(t *testing.T) {
 	}
 }
 
+func TestRead_registry(t *testing.T) {
+	registries := []Registry{
+		{Name: "missing", Dir: filepath.Join("testdata", "missing")},
+		{Name: "local", Dir: filepath.Join("testdata", "registry")},
+	}
+	pkg, err := Read(context.Background(), nil, "nalgeon/example", registries)
+	if err != nil {
+		t.Fatalf("Read: unexpected error %v", err)
+	}
+	if pkg.Registry != "local" {
+		t.Errorf("Read: unexpected registry %q", pkg.Registry)
+	}
+	if pkg.Specfile != filepath.Join("testdata", "registry", "nalgeon", "example.json") {
+		t.Errorf("Read: unexpected specfile %q", pkg.Specfile)
+	}
+}
+
 func Test_inferReader(t *testing.T) {
-	var fnLocal = ReadFunc(ReadLocal)
-	var fnRemote = ReadFunc(ReadRemote)
+	client := httpx.Mock()
 	t.Run("local", func(t *testing.T) {
-		got := inferReader("./testdata/sqlpkg.json")
-		if &got == &fnLocal {
-			t.Errorf("inferReader: unexpected value %#v", got)
+		read := inferReader(context.Background(), client, "./testdata/sqlpkg.json")
+		pkg, err := read("./testdata/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("inferReader: unexpected error %v", err)
+		}
+		if pkg.FullName() != "nalgeon/example" {
+			t.Errorf("inferReader: unexpected package %v", pkg.FullName())
 		}
 	})
 	t.Run("remote", func(t *testing.T) {
-		got := inferReader("https://antonz.org/sqlpkg.json")
-		if &got == &fnRemote {
-			t.Errorf("inferReader: unexpected value %#v", got)
+		read := inferReader(context.Background(), client, "https://antonz.org/sqlpkg.json")
+		pkg, err := read("https://antonz.org/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("inferReader: unexpected error %v", err)
+		}
+		if pkg.FullName() != "nalgeon/example" {
+			t.Errorf("inferReader: unexpected package %v", pkg.FullName())
 		}
 	})
 }
@@ -0,0 +1,72 @@
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// A Registry is a collection of package specs that resolves
+// owner-name pairs (e.g. nalgeon/sqlean) to spec files.
+// URL is a template with {owner} and {name} placeholders
+// (e.g. https://example.org/pkg/{owner}/{name}.json).
+// Dir is a local directory with specs stored as <owner>/<name>.json.
+// A registry has either URL or Dir. Registries with a higher Priority
+// are tried first, the ones with the same Priority are tried in order.
+// Index is an url or a local path to the JSON array of all specs
+// in the registry. Dir registries don't need an index.
+type Registry struct {
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+	Dir      string `json:"dir,omitempty"`
+	Index    string `json:"index,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+}
+
+// DefaultRegistries are used when there are no registries in the config.
+// The first one is the package author's GitHub repository,
+// the second one is the public sqlpkg registry.
+var DefaultRegistries = []Registry{
+	{Name: "github", URL: "https://github.com/{owner}/{name}/raw/main/" + FileName},
+	{
+		Name:  "sqlpkg",
+		URL:   "https://github.com/nalgeon/sqlpkg/raw/main/pkg/{owner}/{name}.json",
+		Index: "https://github.com/nalgeon/sqlpkg/raw/main/index.json",
+	},
+}
+
+// Validate checks if the registry is properly configured.
+func (r Registry) Validate() error {
+	if r.Name == "" {
+		return errors.New("registry name is not set")
+	}
+	if r.URL == "" && r.Dir == "" {
+		return fmt.Errorf("registry %s: either url or dir should be set", r.Name)
+	}
+	if r.URL != "" && r.Dir != "" {
+		return fmt.Errorf("registry %s: url and dir are mutually exclusive", r.Name)
+	}
+	return nil
+}
+
+// SpecPath returns the path to the package spec file in the registry.
+func (r Registry) SpecPath(owner, name string) string {
+	if r.Dir != "" {
+		return filepath.Join(r.Dir, owner, name+".json")
+	}
+	return stringFormat(r.URL, map[string]any{
+		"owner": owner,
+		"name":  name,
+	})
+}
+
+// SortRegistries returns the registries in the order they should be tried.
+func SortRegistries(registries []Registry) []Registry {
+	sorted := make([]Registry, len(registries))
+	copy(sorted, registries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
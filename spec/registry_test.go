@@ -0,0 +1,66 @@
+package spec
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		reg   Registry
+		valid bool
+	}{
+		{"url", Registry{Name: "internal", URL: "https://example.org/{owner}/{name}.json"}, true},
+		{"dir", Registry{Name: "local", Dir: "/opt/registry"}, true},
+		{"no name", Registry{URL: "https://example.org/{owner}/{name}.json"}, false},
+		{"no location", Registry{Name: "empty"}, false},
+		{"both", Registry{Name: "both", URL: "https://example.org", Dir: "/opt/registry"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.reg.Validate()
+			if (err == nil) != test.valid {
+				t.Errorf("Validate: unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestRegistry_SpecPath(t *testing.T) {
+	t.Run("url", func(t *testing.T) {
+		reg := Registry{Name: "internal", URL: "https://example.org/pkg/{owner}/{name}.json"}
+		got := reg.SpecPath("nalgeon", "example")
+		if got != "https://example.org/pkg/nalgeon/example.json" {
+			t.Errorf("SpecPath: unexpected value %q", got)
+		}
+	})
+	t.Run("dir", func(t *testing.T) {
+		reg := Registry{Name: "local", Dir: "/opt/registry"}
+		got := reg.SpecPath("nalgeon", "example")
+		if got != filepath.Join("/opt/registry", "nalgeon", "example.json") {
+			t.Errorf("SpecPath: unexpected value %q", got)
+		}
+	})
+}
+
+func TestSortRegistries(t *testing.T) {
+	registries := []Registry{
+		{Name: "a"},
+		{Name: "b", Priority: 10},
+		{Name: "c"},
+		{Name: "d", Priority: 10},
+	}
+	sorted := SortRegistries(registries)
+	names := []string{}
+	for _, reg := range sorted {
+		names = append(names, reg.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"b", "d", "a", "c"}) {
+		t.Errorf("SortRegistries: unexpected order %v", names)
+	}
+	if registries[0].Name != "a" {
+		t.Error("SortRegistries: modified the original slice")
+	}
+}
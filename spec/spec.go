@@ -9,7 +9,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/assets"
+	"sqlpkg.org/cli/provider"
 )
 
 // DirName is the name of the folder with packages
@@ -18,12 +19,13 @@ const DirName = ".sqlpkg"
 // FileName is the package spec filename.
 const FileName = "sqlpkg.json"
 
-// downloadBase determines default asset url for known providers.
-var downloadBase = map[string]string{
-	"github.com": "{repository}/releases/download/{version}",
-}
-
 // A Package describes the package spec.
+// Publickey is the minisign public key used to sign the checksum file.
+// Registry is the name of the registry the spec was found in (if any).
+// Symbols are the SQL functions (and other symbols) the package defines.
+// If the spec does not list them, they are set to the entry points on install.
+// Entrypoints maps installed library files (relative to the package dir)
+// to the extension entry points they export. It's filled on install.
 type Package struct {
 	Owner       string   `json:"owner"`
 	Name        string   `json:"name"`
@@ -31,20 +33,42 @@ type Package struct {
 	Homepage    string   `json:"homepage,omitempty"`
 	Repository  string   `json:"repository,omitempty"`
 	Specfile    string   `json:"specfile,omitempty"`
+	Registry    string   `json:"registry,omitempty"`
 	Authors     []string `json:"authors,omitempty"`
 	License     string   `json:"license,omitempty"`
 	Description string   `json:"description,omitempty"`
 	Keywords    []string `json:"keywords,omitempty"`
 	Symbols     []string `json:"symbols,omitempty"`
+	Publickey   string   `json:"publickey,omitempty"`
 	Assets      Assets   `json:"assets"`
+
+	Entrypoints map[string][]string `json:"entrypoints,omitempty"`
 }
 
 // Assets are archives of package files, each for a specific platform.
+// Pattern is a single glob that selects files to unpack from the archive.
+// It's kept for backward compatibility, newer specs use Patterns instead.
+// ChecksumFile is the name of the checksum file next to the assets.
+// If empty, common checksum filenames and per-asset sidecar files are tried.
 type Assets struct {
-	Path      *AssetPath        `json:"path"`
-	Pattern   string            `json:"pattern,omitempty"`
-	Files     map[string]string `json:"files"`
-	Checksums map[string]string `json:"checksums,omitempty"`
+	Path         *AssetPath        `json:"path"`
+	Pattern      string            `json:"pattern,omitempty"`
+	Patterns     assets.Patterns   `json:"patterns,omitempty"`
+	Files        map[string]string `json:"files"`
+	ChecksumFile string            `json:"checksumfile,omitempty"`
+	Checksums    map[string]string `json:"checksums,omitempty"`
+}
+
+// FilePatterns returns patterns that select files to unpack from the archive,
+// combining the legacy Pattern with the Patterns list.
+func (a *Assets) FilePatterns() assets.Patterns {
+	if a.Pattern == "" {
+		return a.Patterns
+	}
+	patterns := make(assets.Patterns, 0, len(a.Patterns)+1)
+	patterns = append(patterns, assets.Pattern{Match: a.Pattern})
+	patterns = append(patterns, a.Patterns...)
+	return patterns
 }
 
 // FullName is an owner-name pair that uniquely identifies the package.
@@ -74,6 +98,9 @@ func (p *Package) ExpandVars() {
 			"version": version,
 		})
 	}
+	p.Assets.ChecksumFile = stringFormat(p.Assets.ChecksumFile, map[string]any{
+		"version": version,
+	})
 }
 
 // ReplaceLatest forces a specific package version instead of the "latest" placeholder.
@@ -86,6 +113,7 @@ func (p *Package) ReplaceLatest(version string) {
 	for platform, file := range p.Assets.Files {
 		p.Assets.Files[platform] = strings.Replace(file, "{latest}", version, 1)
 	}
+	p.Assets.ChecksumFile = strings.Replace(p.Assets.ChecksumFile, "{latest}", version, 1)
 }
 
 // AssetPath determines the package url for a specific platform (OS + architecture).
@@ -123,9 +151,13 @@ func Path(basePath, owner, name string) string {
 }
 
 // inferAssetUrl determines an asset url given the package repository url.
+// Works for known providers (github.com, gitlab.com and codeberg.org).
 func inferAssetUrl(repoUrl string) string {
-	hostname := httpx.Hostname(repoUrl)
-	return downloadBase[hostname]
+	prov, _ := provider.ForRepo(repoUrl, nil)
+	if prov == nil {
+		return ""
+	}
+	return prov.DownloadBase()
 }
 
 // stringFormat formats a string according to the map of values.
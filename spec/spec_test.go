@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"sqlpkg.org/cli/assets"
 	"sqlpkg.org/cli/fileio"
 )
 
@@ -70,6 +71,20 @@ func TestPackage_ExpandVars(t *testing.T) {
 			t.Errorf("ExpandVars: unexpected Assets.Files = %v", p.Assets.Files["windows-amd64"])
 		}
 	})
+	t.Run("expand checksum file", func(t *testing.T) {
+		p := &Package{
+			Owner: "nalgeon", Name: "example", Version: "0.1.0",
+			Assets: Assets{
+				Files:        map[string]string{"linux-amd64": "example-linux-{version}-x86.zip"},
+				ChecksumFile: "example-{version}.sha256",
+			},
+		}
+
+		p.ExpandVars()
+		if p.Assets.ChecksumFile != "example-0.1.0.sha256" {
+			t.Errorf("ExpandVars: unexpected Assets.ChecksumFile = %v", p.Assets.ChecksumFile)
+		}
+	})
 	t.Run("latest version", func(t *testing.T) {
 		p := &Package{
 			Owner: "nalgeon", Name: "example", Version: "latest",
@@ -92,6 +107,31 @@ func TestPackage_ExpandVars(t *testing.T) {
 	})
 }
 
+func TestAssets_FilePatterns(t *testing.T) {
+	t.Run("patterns", func(t *testing.T) {
+		a := Assets{Patterns: assets.Patterns{{Match: "*.so"}, {Match: "!test*"}}}
+		got := a.FilePatterns()
+		if !reflect.DeepEqual(got, a.Patterns) {
+			t.Errorf("FilePatterns: unexpected value %v", got)
+		}
+	})
+	t.Run("legacy pattern", func(t *testing.T) {
+		a := Assets{Pattern: "*.dylib", Patterns: assets.Patterns{{Match: "LICENSE"}}}
+		got := a.FilePatterns()
+		want := assets.Patterns{{Match: "*.dylib"}, {Match: "LICENSE"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FilePatterns: unexpected value %v", got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		a := Assets{}
+		got := a.FilePatterns()
+		if len(got) != 0 {
+			t.Errorf("FilePatterns: unexpected value %v", got)
+		}
+	})
+}
+
 func TestPackage_ReplaceLatest(t *testing.T) {
 	t.Run("latest", func(t *testing.T) {
 		p := &Package{
@@ -277,6 +317,16 @@ func Test_inferAssetUrl(t *testing.T) {
 			"https://github.com/nalgeon/sqlite-example",
 			"{repository}/releases/download/{version}",
 		},
+		{
+			"gitlab",
+			"https://gitlab.com/nalgeon/sqlite-example",
+			"{repository}/-/releases/{version}/downloads",
+		},
+		{
+			"codeberg",
+			"https://codeberg.org/nalgeon/sqlite-example",
+			"{repository}/releases/download/{version}",
+		},
 		{
 			"custom",
 			"https://antonz.org/sqlite-example",
@@ -0,0 +1,209 @@
+// Functions that manage package assets.
+package sqlpkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"sqlpkg.org/cli/assets"
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/provider"
+	"sqlpkg.org/cli/spec"
+)
+
+// AssetTempDirName is the name of the temporary directory for downloading assets.
+const AssetTempDirName = ".tmp"
+
+// AssetTempDir returns the temporary directory for downloading assets.
+func (m *Manager) AssetTempDir() string {
+	return filepath.Join(m.RepoDir(), AssetTempDirName)
+}
+
+// buildAssetPath constructs an URL to download package asset.
+func (m *Manager) buildAssetPath(ctx context.Context, pkg *spec.Package) (*spec.AssetPath, error) {
+	m.Logger.Debug("checking remote asset for platform %s", m.Platform)
+	m.Logger.Debug("asset base path = %s", pkg.Assets.Path)
+
+	assetPath, err := pkg.AssetPath(m.Platform.OS, m.Platform.Arch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupported, m.Platform)
+	}
+
+	if useAssetAPI(assetPath) {
+		// the asset is resolved through the GitHub API on download,
+		// the public url does not work for private repositories
+		return assetPath, nil
+	}
+
+	if !assetPath.Exists(ctx, m.Client) {
+		return nil, fmt.Errorf("asset does not exist: %s", assetPath)
+	}
+
+	return assetPath, nil
+}
+
+// downloadAsset downloads package asset.
+func (m *Manager) downloadAsset(ctx context.Context, pkg *spec.Package, assetPath *spec.AssetPath) (*assets.Asset, error) {
+	m.Logger.Debug("downloading %s", assetPath)
+	dir := filepath.Join(m.AssetTempDir(), pkg.Owner, pkg.Name)
+	err := fileio.CreateDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	var asset *assets.Asset
+	if useAssetAPI(assetPath) {
+		asset, err = m.downloadReleaseAsset(ctx, dir, assetPath)
+	} else if assetPath.IsRemote {
+		asset, err = assets.Download(ctx, m.Client, dir, assetPath.Value)
+	} else {
+		asset, err = assets.Copy(dir, assetPath.Value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download asset: %w", err)
+	}
+
+	sizeKb := float64(asset.Size) / 1024
+	m.Logger.Debug("downloaded %s (%.2f Kb)", asset.Name, sizeKb)
+	return asset, nil
+}
+
+// downloadReleaseAsset downloads the GitHub release asset
+// through the GitHub API.
+func (m *Manager) downloadReleaseAsset(ctx context.Context, dir string, assetPath *spec.AssetPath) (*assets.Asset, error) {
+	apiUrl, err := provider.GitHubCom.AssetURL(ctx, m.Client, assetPath.Value)
+	if err != nil {
+		return nil, err
+	}
+	m.Logger.Debug("using release asset api url %s", apiUrl)
+	name := path.Base(assetPath.Value)
+	return assets.DownloadAs(ctx, m.Client, dir, name, apiUrl)
+}
+
+// useAssetAPI checks if the asset should be downloaded through
+// the GitHub API. This is the case for release assets
+// when there is a GitHub token (the repository may be private).
+func useAssetAPI(assetPath *spec.AssetPath) bool {
+	token, _ := httpx.GitHubToken()
+	return token != "" && assetPath.IsRemote && provider.GitHubCom.IsReleaseAsset(assetPath.Value)
+}
+
+// validateAsset checks if the asset is valid.
+// If the checksum is required, fails when there is no checksum to verify
+// the asset against. Otherwise, warns about the unverified asset.
+func (m *Manager) validateAsset(pkg *spec.Package, asset *assets.Asset, requireChecksum bool) error {
+	checksumStr, ok := pkg.Assets.Checksums[asset.Name]
+	if !ok {
+		if requireChecksum {
+			return ErrMissingChecksum
+		}
+		m.Logger.Warn("spec is missing asset checksum, installing unverified asset %s", asset.Name)
+		return nil
+	}
+
+	ok, err := asset.Validate(checksumStr)
+	if err != nil {
+		return fmt.Errorf("failed to validate asset: %w", err)
+	}
+
+	if !ok {
+		return ErrInvalidChecksum
+	}
+
+	m.Logger.Debug("asset checksum is valid")
+	return nil
+}
+
+// unpackAsset unpacks package asset.
+func (m *Manager) unpackAsset(pkg *spec.Package, asset *assets.Asset) error {
+	nFiles, err := assets.Unpack(asset.Path, pkg.Assets.FilePatterns())
+	if err != nil {
+		return fmt.Errorf("failed to unpack asset: %w", err)
+	}
+	if nFiles == 0 {
+		m.Logger.Debug("not an archive, skipping unpack: %s", asset.Name)
+		return nil
+	}
+	err = os.Remove(asset.Path)
+	if err != nil {
+		return fmt.Errorf("failed to delete asset after unpacking: %w", err)
+	}
+	m.Logger.Debug("unpacked %d files from %s", nFiles, asset.Name)
+	return nil
+}
+
+// cleanupStaging removes the package staging dir, along with
+// the parent dirs if they are empty. Does nothing if there is no staging dir
+// (e.g. the files are already installed).
+func (m *Manager) cleanupStaging(pkg *spec.Package) {
+	dir := filepath.Join(m.AssetTempDir(), pkg.Owner, pkg.Name)
+	if !fileio.Exists(dir) {
+		return
+	}
+	err := os.RemoveAll(dir)
+	if err != nil {
+		m.Logger.Debug("failed to remove staging dir: %s", err)
+		return
+	}
+	m.Logger.Debug("removed staging dir %s", dir)
+	// os.Remove fails on non-empty dirs, which is what we want
+	_ = os.Remove(filepath.Dir(dir))
+	_ = os.Remove(m.AssetTempDir())
+}
+
+// installFiles installes unpacked package files.
+func (m *Manager) installFiles(pkg *spec.Package, asset *assets.Asset) error {
+	pkgDir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+	err := fileio.MoveDir(asset.Dir(), pkgDir)
+	if err != nil {
+		return fmt.Errorf("failed to copy downloaded files: %w", err)
+	}
+
+	err = m.findEntrypoints(pkg, pkgDir)
+	if err != nil {
+		return err
+	}
+
+	err = pkg.Save(pkgDir)
+	if err != nil {
+		return fmt.Errorf("failed to write package spec: %w", err)
+	}
+
+	err = m.writeManifest(pkg)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// dequarantineFiles removes the macOS quarantine flag
+// from all *.dylib files in the package directory.
+func (m *Manager) dequarantineFiles(pkg *spec.Package) error {
+	if m.Platform.OS != "darwin" {
+		return nil
+	}
+
+	pattern := filepath.Join(spec.Dir(m.Dir, pkg.Owner, pkg.Name), "*.dylib")
+	paths, _ := filepath.Glob(pattern)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	var allErr error
+	for _, path := range paths {
+		err := fileio.Dequarantine(path)
+		allErr = errors.Join(allErr, err)
+	}
+	if allErr != nil {
+		return fmt.Errorf("failed to dequarantine files: %w", allErr)
+	}
+
+	m.Logger.Debug("removed %d files from quarantine", len(paths))
+	return nil
+}
@@ -1,6 +1,7 @@
-package cmd
+package sqlpkg
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,15 +11,16 @@ import (
 
 	"sqlpkg.org/cli/assets"
 	"sqlpkg.org/cli/fileio"
-	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/manifest"
 	"sqlpkg.org/cli/spec"
 )
 
 func TestAssetTempDir(t *testing.T) {
-	defer os.RemoveAll(AssetTempDir())
+	m := testManager()
+	defer os.RemoveAll(m.AssetTempDir())
 	t.Run("dir name", func(t *testing.T) {
-		dir := AssetTempDir()
-		wantDir := filepath.Join(WorkDir, ".sqlpkg/.tmp")
+		dir := m.AssetTempDir()
+		wantDir := filepath.Join(m.Dir, ".sqlpkg/.tmp")
 		if dir != wantDir {
 			t.Errorf("AssetTempDir: unexpected value %v", dir)
 		}
@@ -26,7 +28,8 @@ func TestAssetTempDir(t *testing.T) {
 }
 
 func TestBuildAssetPath(t *testing.T) {
-	httpx.Mock()
+	ctx := context.Background()
+	m := testManager()
 	t.Run("exists", func(t *testing.T) {
 		pkg := &spec.Package{
 			Owner: "nalgeon", Name: "example", Version: "0.1.0",
@@ -39,7 +42,7 @@ func TestBuildAssetPath(t *testing.T) {
 			},
 		}
 
-		path, err := BuildAssetPath(pkg)
+		path, err := m.buildAssetPath(ctx, pkg)
 		if err != nil {
 			t.Fatalf("BuildAssetPath: unexpected error %v", err)
 		}
@@ -56,7 +59,7 @@ func TestBuildAssetPath(t *testing.T) {
 			Owner: "nalgeon", Name: "example", Version: "0.1.0",
 		}
 
-		_, err := BuildAssetPath(pkg)
+		_, err := m.buildAssetPath(ctx, pkg)
 		if err == nil {
 			t.Fatal("BuildAssetPath: expected error, got nil")
 		}
@@ -64,8 +67,9 @@ func TestBuildAssetPath(t *testing.T) {
 }
 
 func TestDownloadAsset(t *testing.T) {
-	httpx.Mock()
-	defer os.RemoveAll(AssetTempDir())
+	ctx := context.Background()
+	m := testManager()
+	defer os.RemoveAll(m.AssetTempDir())
 	t.Run("http", func(t *testing.T) {
 		pkg := &spec.Package{
 			Owner: "nalgeon", Name: "example", Version: "0.1.0",
@@ -82,14 +86,14 @@ func TestDownloadAsset(t *testing.T) {
 			IsRemote: true,
 		}
 
-		asset, err := DownloadAsset(pkg, path)
+		asset, err := m.downloadAsset(ctx, pkg, path)
 		if err != nil {
 			t.Fatalf("DownloadAsset: unexpected error %v", err)
 		}
 		if asset.Name != fmt.Sprintf("example-%s.zip", runtime.GOOS) {
 			t.Errorf("DownloadAsset: unexpected Name %v", asset.Name)
 		}
-		wantDir := filepath.Join(WorkDir, ".sqlpkg/.tmp/nalgeon/example")
+		wantDir := filepath.Join(m.Dir, ".sqlpkg/.tmp/nalgeon/example")
 		if asset.Dir() != wantDir {
 			t.Errorf("DownloadAsset: unexpected Dir %v", asset.Dir())
 		}
@@ -122,11 +126,11 @@ func TestDownloadAsset(t *testing.T) {
 			IsRemote: true,
 		}
 
-		asset, err := DownloadAsset(pkg, path)
+		asset, err := m.downloadAsset(ctx, pkg, path)
 		if err != nil {
 			t.Fatalf("DownloadAsset: unexpected error %v", err)
 		}
-		wantDir := filepath.Join(WorkDir, ".sqlpkg/.tmp/nalgeon/example")
+		wantDir := filepath.Join(m.Dir, ".sqlpkg/.tmp/nalgeon/example")
 		if asset.Dir() != wantDir {
 			t.Errorf("DownloadAsset: unexpected Dir %v", asset.Dir())
 		}
@@ -162,7 +166,7 @@ func TestDownloadAsset(t *testing.T) {
 			IsRemote: true,
 		}
 
-		_, err := DownloadAsset(pkg, path)
+		_, err := m.downloadAsset(ctx, pkg, path)
 		if err == nil {
 			t.Fatal("DownloadAsset: expected error, got nil")
 		}
@@ -170,6 +174,7 @@ func TestDownloadAsset(t *testing.T) {
 }
 
 func TestValidateAsset(t *testing.T) {
+	m := testManager()
 	t.Run("valid", func(t *testing.T) {
 		pkg := &spec.Package{
 			Owner: "nalgeon", Name: "example", Version: "0.1.0",
@@ -192,7 +197,7 @@ func TestValidateAsset(t *testing.T) {
 			Checksum: []byte{0x17, 0xe2, 0xf2, 0xf9, 0x71, 0x93},
 		}
 
-		err := ValidateAsset(pkg, asset)
+		err := m.validateAsset(pkg, asset, false)
 		if err != nil {
 			t.Errorf("ValidateAsset: unexpected error %v", err)
 		}
@@ -215,11 +220,34 @@ func TestValidateAsset(t *testing.T) {
 			Checksum: []byte{0x17, 0xe2, 0xf2, 0xf9, 0x71, 0x93},
 		}
 
-		err := ValidateAsset(pkg, asset)
+		err := m.validateAsset(pkg, asset, false)
 		if err != nil {
 			t.Errorf("ValidateAsset: unexpected error %v", err)
 		}
 	})
+	t.Run("missing required", func(t *testing.T) {
+		pkg := &spec.Package{
+			Owner: "nalgeon", Name: "example", Version: "0.1.0",
+			Assets: spec.Assets{
+				Path: &spec.AssetPath{Value: "https://antonz.org", IsRemote: true},
+				Files: map[string]string{
+					"darwin-arm64": "example-darwin.zip",
+					"linux-amd64":  "example-linux.zip",
+				},
+			},
+		}
+		asset := &assets.Asset{
+			Name:     "example-darwin.zip",
+			Path:     "./testdata/example-darwin.zip",
+			Size:     137,
+			Checksum: []byte{0x17, 0xe2, 0xf2, 0xf9, 0x71, 0x93},
+		}
+
+		err := m.validateAsset(pkg, asset, true)
+		if err == nil {
+			t.Fatal("ValidateAsset: expected error, got nil")
+		}
+	})
 	t.Run("invalid", func(t *testing.T) {
 		pkg := &spec.Package{
 			Owner: "nalgeon", Name: "example", Version: "0.1.0",
@@ -242,7 +270,7 @@ func TestValidateAsset(t *testing.T) {
 			Checksum: []byte{0x51, 0x52, 0x53, 0x54, 0x55, 0x56},
 		}
 
-		err := ValidateAsset(pkg, asset)
+		err := m.validateAsset(pkg, asset, false)
 		if err == nil {
 			t.Fatal("ValidateAsset: expected error, got nil")
 		}
@@ -250,6 +278,7 @@ func TestValidateAsset(t *testing.T) {
 }
 
 func TestUnpackAsset(t *testing.T) {
+	m := testManager()
 	t.Run("archived", func(t *testing.T) {
 		dir := t.TempDir()
 		path := filepath.Join(dir, "example-darwin.zip")
@@ -265,7 +294,7 @@ func TestUnpackAsset(t *testing.T) {
 			Name: "example-darwin.zip", Path: path,
 		}
 
-		err = UnpackAsset(pkg, asset)
+		err = m.unpackAsset(pkg, asset)
 		if err != nil {
 			t.Fatalf("UnpackAsset: unexpected error %v", err)
 		}
@@ -300,7 +329,7 @@ func TestUnpackAsset(t *testing.T) {
 			Name: "example.dylib", Path: path,
 		}
 
-		err = UnpackAsset(pkg, asset)
+		err = m.unpackAsset(pkg, asset)
 		if err != nil {
 			t.Fatalf("UnpackAsset: unexpected error %v", err)
 		}
@@ -320,8 +349,9 @@ func TestUnpackAsset(t *testing.T) {
 }
 
 func TestInstallFiles(t *testing.T) {
-	SetupTestRepo(t)
-	defer TeardownTestRepo(t)
+	m := testManager()
+	setupTestRepo(t)
+	defer teardownTestRepo(t)
 
 	dir := t.TempDir()
 	path := filepath.Join(dir, "example.dylib")
@@ -337,15 +367,18 @@ func TestInstallFiles(t *testing.T) {
 		Name: "example.dylib", Path: path,
 	}
 
-	err = InstallFiles(pkg, asset)
+	err = m.installFiles(pkg, asset)
 	if err != nil {
 		t.Fatalf("InstallFiles: unexpected error %v", err)
 	}
-	if !fileio.Exists(spec.Path(WorkDir, pkg.Owner, pkg.Name)) {
+	if !fileio.Exists(spec.Path(m.Dir, pkg.Owner, pkg.Name)) {
 		t.Errorf("InstallFiles: package spec is not installed")
 	}
-	installedPath := filepath.Join(spec.Dir(WorkDir, pkg.Owner, pkg.Name), asset.Name)
+	installedPath := filepath.Join(spec.Dir(m.Dir, pkg.Owner, pkg.Name), asset.Name)
 	if !fileio.Exists(installedPath) {
 		t.Errorf("InstallFiles: package asset is not installed")
 	}
+	if !fileio.Exists(manifest.Path(spec.Dir(m.Dir, pkg.Owner, pkg.Name))) {
+		t.Errorf("InstallFiles: package manifest is not written")
+	}
 }
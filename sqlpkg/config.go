@@ -0,0 +1,108 @@
+// Functions that manage sqlpkg settings.
+package sqlpkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/spec"
+)
+
+// ReadConfig reads settings from the work directory.
+// Returns default settings if there is no config file.
+func (m *Manager) ReadConfig() (*config.Config, error) {
+	path := config.Path(m.Dir)
+	if !fileio.Exists(path) {
+		return config.Default(), nil
+	}
+
+	cfg, err := config.ReadLocal(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	m.Logger.Debug("read config from %s", path)
+	return cfg, nil
+}
+
+// registries returns the registries from the config in the order
+// they should be tried, or the default ones if there are none.
+// Relative registry dirs and index paths are resolved against the root dir.
+func (m *Manager) registries() ([]spec.Registry, error) {
+	cfg, err := m.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Registries) == 0 {
+		return spec.DefaultRegistries, nil
+	}
+
+	registries := spec.SortRegistries(cfg.Registries)
+	for i, reg := range registries {
+		err := reg.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+		if reg.Dir != "" && !filepath.IsAbs(reg.Dir) {
+			registries[i].Dir = filepath.Join(m.Dir, reg.Dir)
+		}
+		if reg.Index != "" && !httpx.IsURL(reg.Index) && !filepath.IsAbs(reg.Index) {
+			registries[i].Index = filepath.Join(m.Dir, reg.Index)
+		}
+	}
+	return registries, nil
+}
+
+// hostTokens returns the bearer tokens from the config, mapped by host name.
+func (m *Manager) hostTokens() (map[string]string, error) {
+	cfg, err := m.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Tokens, nil
+}
+
+// credentialHelpers returns the credential helpers from the user's
+// global config, mapped by host name. Ignores the project config,
+// so that a cloned project cannot make sqlpkg run its programs.
+func (m *Manager) credentialHelpers() (map[string]string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, nil
+	}
+	path := config.Path(home)
+	if !fileio.Exists(path) {
+		return nil, nil
+	}
+	cfg, err := config.ReadLocal(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	return cfg.CredentialHelpers, nil
+}
+
+// requireChecksums checks if assets must have verifiable checksums,
+// either because of the option or the config setting.
+func (m *Manager) requireChecksums(flag bool) (bool, error) {
+	if flag {
+		return true, nil
+	}
+	cfg, err := m.ReadConfig()
+	if err != nil {
+		return false, err
+	}
+	return cfg.RequireChecksums, nil
+}
+
+// SaveConfig writes settings to the work directory.
+func (m *Manager) SaveConfig(cfg *config.Config) error {
+	err := cfg.Save(m.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package sqlpkg
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestManager_registries(t *testing.T) {
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+
+	t.Run("default", func(t *testing.T) {
+		got, err := m.registries()
+		if err != nil {
+			t.Fatalf("registries: unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(got, spec.DefaultRegistries) {
+			t.Errorf("registries: unexpected value %+v", got)
+		}
+	})
+	t.Run("configured", func(t *testing.T) {
+		m.Dir = t.TempDir()
+		cfg := &config.Config{Registries: []spec.Registry{
+			{Name: "local", Dir: "registry", Index: "index.json"},
+			{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Priority: 10},
+		}}
+		err := m.SaveConfig(cfg)
+		if err != nil {
+			t.Fatalf("SaveConfig: unexpected error %v", err)
+		}
+
+		got, err := m.registries()
+		if err != nil {
+			t.Fatalf("registries: unexpected error %v", err)
+		}
+		want := []spec.Registry{
+			{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Priority: 10},
+			{Name: "local", Dir: filepath.Join(m.Dir, "registry"), Index: filepath.Join(m.Dir, "index.json")},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("registries: unexpected value %+v", got)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		m.Dir = t.TempDir()
+		cfg := &config.Config{Registries: []spec.Registry{{Name: "empty"}}}
+		err := m.SaveConfig(cfg)
+		if err != nil {
+			t.Fatalf("SaveConfig: unexpected error %v", err)
+		}
+
+		_, err = m.registries()
+		if err == nil || !strings.HasPrefix(err.Error(), "invalid config") {
+			t.Fatalf("registries: unexpected error %v", err)
+		}
+	})
+}
+
+func TestManager_credentialHelpers(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	m := New(t.TempDir())
+	project := &config.Config{CredentialHelpers: map[string]string{"*": "project"}}
+	err := project.Save(m.Dir)
+	if err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	t.Run("project", func(t *testing.T) {
+		got, err := m.credentialHelpers()
+		if err != nil || got != nil {
+			t.Errorf("credentialHelpers: unexpected result %v, %v", got, err)
+		}
+	})
+	t.Run("global", func(t *testing.T) {
+		global := &config.Config{CredentialHelpers: map[string]string{"*": "global"}}
+		err := global.Save(home)
+		if err != nil {
+			t.Fatalf("Save: unexpected error %v", err)
+		}
+		got, err := m.credentialHelpers()
+		if err != nil || got["*"] != "global" {
+			t.Errorf("credentialHelpers: unexpected result %v, %v", got, err)
+		}
+	})
+}
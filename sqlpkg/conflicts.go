@@ -0,0 +1,80 @@
+// Functions that detect symbol conflicts between packages.
+package sqlpkg
+
+import (
+	"sort"
+	"strings"
+
+	"sqlpkg.org/cli/spec"
+)
+
+// A Conflict describes symbols defined by both the package
+// and another installed package. When both packages are loaded,
+// one of them silently shadows the other's symbols.
+type Conflict struct {
+	Package string
+	Symbols []string
+}
+
+// FindConflicts returns installed packages that define
+// the same symbols (SQL functions) as the given package.
+// Symbols are compared case-insensitively. Extension entry points
+// are not compared: SQLite calls the entry point of each library
+// explicitly, so libraries sharing one (e.g. sqlite3_extension_init)
+// do not shadow each other.
+func (m *Manager) FindConflicts(pkg *spec.Package) ([]Conflict, error) {
+	installed, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	own := packageSymbols(pkg)
+	if len(own) == 0 {
+		return nil, nil
+	}
+
+	conflicts := []Conflict{}
+	for _, other := range installed {
+		if other.FullName() == pkg.FullName() {
+			continue
+		}
+		shared := []string{}
+		for key := range packageSymbols(other) {
+			if sym, ok := own[key]; ok {
+				shared = append(shared, sym)
+			}
+		}
+		if len(shared) == 0 {
+			continue
+		}
+		sort.Strings(shared)
+		conflicts = append(conflicts, Conflict{Package: other.FullName(), Symbols: shared})
+	}
+
+	m.Logger.Debug("found %d conflicting packages for %s", len(conflicts), pkg.FullName())
+	return conflicts, nil
+}
+
+// warnConflicts prints a warning for each installed package
+// that defines the same symbols as the package.
+// Does not fail the install if the check itself fails.
+func (m *Manager) warnConflicts(pkg *spec.Package) {
+	conflicts, err := m.FindConflicts(pkg)
+	if err != nil {
+		m.Logger.Warn("failed to check symbol conflicts: %s", err)
+		return
+	}
+	for _, c := range conflicts {
+		m.Logger.Warn("%s also defines %s", c.Package, strings.Join(c.Symbols, ", "))
+	}
+}
+
+// packageSymbols returns the symbols the package defines,
+// keyed by their lowercase names.
+func packageSymbols(pkg *spec.Package) map[string]string {
+	symbols := map[string]string{}
+	for _, sym := range pkg.Symbols {
+		symbols[strings.ToLower(sym)] = sym
+	}
+	return symbols
+}
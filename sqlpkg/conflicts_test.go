@@ -0,0 +1,81 @@
+package sqlpkg
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"sqlpkg.org/cli/spec"
+)
+
+func TestManager_FindConflicts(t *testing.T) {
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+
+	installed := []*spec.Package{
+		{Owner: "nalgeon", Name: "stats", Symbols: []string{"median", "stddev"}},
+		{
+			Owner:       "sqlite",
+			Name:        "vec",
+			Symbols:     []string{"vec_distance"},
+			Entrypoints: map[string][]string{"vec.so": {"sqlite3_extension_init"}},
+		},
+	}
+	for _, pkg := range installed {
+		dir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+		err := os.MkdirAll(dir, 0755)
+		if err != nil {
+			t.Fatalf("MkdirAll: unexpected error %v", err)
+		}
+		err = pkg.Save(dir)
+		if err != nil {
+			t.Fatalf("Save: unexpected error %v", err)
+		}
+	}
+
+	t.Run("symbols", func(t *testing.T) {
+		pkg := &spec.Package{Owner: "asg017", Name: "stats", Symbols: []string{"MEDIAN", "stddev", "mode"}}
+		got, err := m.FindConflicts(pkg)
+		if err != nil {
+			t.Fatalf("FindConflicts: unexpected error %v", err)
+		}
+		want := []Conflict{{Package: "nalgeon/stats", Symbols: []string{"MEDIAN", "stddev"}}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("FindConflicts: expected %v, got %v", want, got)
+		}
+	})
+	t.Run("entrypoints", func(t *testing.T) {
+		pkg := &spec.Package{
+			Owner:       "asg017",
+			Name:        "vec",
+			Symbols:     []string{"vec_length"},
+			Entrypoints: map[string][]string{"vec0.so": {"sqlite3_extension_init"}},
+		}
+		got, err := m.FindConflicts(pkg)
+		if err != nil {
+			t.Fatalf("FindConflicts: unexpected error %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("FindConflicts: unexpected conflicts %v", got)
+		}
+	})
+	t.Run("self", func(t *testing.T) {
+		got, err := m.FindConflicts(installed[0])
+		if err != nil {
+			t.Fatalf("FindConflicts: unexpected error %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("FindConflicts: unexpected conflicts %v", got)
+		}
+	})
+	t.Run("none", func(t *testing.T) {
+		pkg := &spec.Package{Owner: "nalgeon", Name: "text", Symbols: []string{"text_split"}}
+		got, err := m.FindConflicts(pkg)
+		if err != nil {
+			t.Fatalf("FindConflicts: unexpected error %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("FindConflicts: unexpected conflicts %v", got)
+		}
+	})
+}
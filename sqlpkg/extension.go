@@ -0,0 +1,276 @@
+// Functions that locate installed extension files.
+package sqlpkg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sqlpkg.org/cli/binfile"
+	"sqlpkg.org/cli/spec"
+)
+
+// genericEntrypoint is the entry point SQLite tries first
+// if the entry point is not specified.
+const genericEntrypoint = "sqlite3_extension_init"
+
+// ExtensionsEnvVar is the environment variable
+// that lists paths to the extension files.
+const ExtensionsEnvVar = "SQLPKG_EXTENSIONS"
+
+// maps the OS name to the file extension
+var fileExt = map[string]string{
+	"darwin":  ".dylib",
+	"linux":   ".so",
+	"windows": ".dll",
+}
+
+// An Extension is an installed extension file to load into SQLite.
+// Entrypoint is empty if it's unknown.
+type Extension struct {
+	Package    string `json:"package"`
+	Path       string `json:"path"`
+	Entrypoint string `json:"entrypoint,omitempty"`
+}
+
+// LoadCommand returns the sqlite3 shell command that loads the extension.
+func (e Extension) LoadCommand() string {
+	if e.Entrypoint == "" {
+		return ".load " + dotQuote(e.Path)
+	}
+	return ".load " + dotQuote(e.Path) + " " + e.Entrypoint
+}
+
+// LoadStatement returns the SQL statement that loads the extension.
+func (e Extension) LoadStatement() string {
+	if e.Entrypoint == "" {
+		return fmt.Sprintf("select load_extension(%s);", sqlQuote(e.Path))
+	}
+	return fmt.Sprintf("select load_extension(%s, %s);", sqlQuote(e.Path), sqlQuote(e.Entrypoint))
+}
+
+// JoinPaths returns extension paths as a single string
+// separated by the OS-specific path list separator (e.g. ':').
+func JoinPaths(exts []Extension) string {
+	paths := make([]string, len(exts))
+	for i, ext := range exts {
+		paths[i] = ext.Path
+	}
+	return strings.Join(paths, string(os.PathListSeparator))
+}
+
+// FindExtensions returns extension files of the installed package
+// for the manager's platform along with their entry points.
+// Returns the file named after the package if there is one,
+// or all files with the OS-specific extension otherwise.
+func (m *Manager) FindExtensions(pkg *spec.Package) ([]Extension, error) {
+	pkgDir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+	paths, _ := FindExtensionFiles(pkgDir, pkg.Name, m.Platform.OS)
+	if len(paths) == 0 {
+		return nil, ErrExtensionNotFound
+	}
+
+	return m.describeFiles(pkg, paths), nil
+}
+
+// SelectExtensions returns extension files of the installed packages
+// that match any of the include globs (or all packages if there are none)
+// and none of the exclude globs. Packages go in the order of their full names,
+// extension paths are absolute. Skips packages without extension files.
+func (m *Manager) SelectExtensions(include, exclude []string) ([]Extension, error) {
+	packages, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	exts := []Extension{}
+	for _, pkg := range packages {
+		if !matchPackage(pkg.FullName(), include, exclude) {
+			m.Logger.Debug("excluded %s", pkg.FullName())
+			continue
+		}
+		found, err := m.FindExtensions(pkg)
+		if err != nil {
+			m.Logger.Debug("skipping %s: %s", pkg.FullName(), err)
+			continue
+		}
+		for _, ext := range found {
+			ext.Path, err = filepath.Abs(ext.Path)
+			if err != nil {
+				return nil, err
+			}
+			exts = append(exts, ext)
+		}
+	}
+	return exts, nil
+}
+
+// FindExtensionFiles returns paths to extension files in the package dir.
+// If there is a file with the same name as the package itself,
+// returns it as an exact match. Otherwise, returns all files
+// that have an expected extension (e.g. textext.dylib).
+func FindExtensionFiles(pkgDir, name, os string) (paths []string, exact bool) {
+	path := findExact(pkgDir, name, os)
+	if path != "" {
+		return []string{path}, true
+	}
+	return findByExt(pkgDir, os), false
+}
+
+// Entrypoints returns extension entry points exported by the library file.
+// Uses the entry points recorded in the installed spec if there are any,
+// otherwise reads them from the file.
+func (m *Manager) Entrypoints(pkg *spec.Package, path string) ([]string, error) {
+	if pkg.Entrypoints != nil {
+		pkgDir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+		name, _ := filepath.Rel(pkgDir, path)
+		return pkg.Entrypoints[filepath.ToSlash(name)], nil
+	}
+	m.Logger.Debug("entry points are not recorded, reading from %s", path)
+	entries, err := binfile.EntryPoints(path)
+	if errors.Is(err, binfile.ErrUnknownFormat) {
+		return nil, nil
+	}
+	return entries, err
+}
+
+// describeFiles returns the package extension files
+// along with the entry points to load them with.
+func (m *Manager) describeFiles(pkg *spec.Package, paths []string) []Extension {
+	exts := make([]Extension, len(paths))
+	for i, path := range paths {
+		entries, err := m.Entrypoints(pkg, path)
+		if err != nil {
+			m.Logger.Debug("failed to read entry points from %s: %s", path, err)
+		}
+		exts[i] = Extension{
+			Package:    pkg.FullName(),
+			Path:       path,
+			Entrypoint: chooseEntrypoint(path, entries),
+		}
+	}
+	return exts
+}
+
+// matchPackage checks if the package matches any of the include globs
+// (or there are none) and does not match any of the exclude globs.
+func matchPackage(fullName string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if ok, _ := path.Match(pattern, fullName); ok {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if ok, _ := path.Match(pattern, fullName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// findExact returns a path to the extension file
+// if the extension file has the same name as the package itself.
+func findExact(pkgDir, name, os string) string {
+	{
+		// e.g., text.dylib
+		pattern := filepath.Join(pkgDir, name+fileExt[os])
+		paths, _ := filepath.Glob(pattern)
+		if len(paths) != 0 {
+			return paths[0]
+		}
+	}
+	{
+		// e.g., text0.dylib
+		pattern := filepath.Join(pkgDir, name+"[0-9]"+fileExt[os])
+		paths, _ := filepath.Glob(pattern)
+		if len(paths) != 0 {
+			return paths[0]
+		}
+	}
+	{
+		// e.g., libtext.dylib
+		pattern := filepath.Join(pkgDir, "lib"+name+fileExt[os])
+		paths, _ := filepath.Glob(pattern)
+		if len(paths) != 0 {
+			return paths[0]
+		}
+	}
+	// no exact match
+	return ""
+}
+
+// findByExt returns paths to files in the package dir
+// that have an expected extension (e.g. textext.dylib)
+func findByExt(pkgDir, os string) []string {
+	file := "*" + fileExt[os]
+	pattern := filepath.Join(pkgDir, file)
+	paths, _ := filepath.Glob(pattern)
+	return paths
+}
+
+// chooseEntrypoint selects the entry point to load the extension file with.
+// Prefers the entry point SQLite derives from the file name
+// (e.g. sqlite3_text_init for libtext.so), then the specific ones
+// over the generic sqlite3_extension_init.
+func chooseEntrypoint(path string, entries []string) string {
+	switch len(entries) {
+	case 0:
+		return ""
+	case 1:
+		return entries[0]
+	}
+	derived := derivedEntrypoint(path)
+	for _, entry := range entries {
+		if entry == derived {
+			return entry
+		}
+	}
+	for _, entry := range entries {
+		if entry != genericEntrypoint {
+			return entry
+		}
+	}
+	return entries[0]
+}
+
+// derivedEntrypoint returns the entry point name SQLite derives
+// from the file name: the lowercase ASCII letters of the name
+// up to the first dot, without the "lib" prefix.
+func derivedEntrypoint(path string) string {
+	name := filepath.Base(path)
+	name = strings.TrimPrefix(name, "lib")
+	name, _, _ = strings.Cut(name, ".")
+	var b strings.Builder
+	for _, c := range name {
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			b.WriteRune(c)
+		}
+	}
+	return "sqlite3_" + strings.ToLower(b.String()) + "_init"
+}
+
+// sqlQuote returns the value as an SQL string literal.
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// dotQuote quotes the sqlite3 shell command argument if necessary.
+// Single-quoted arguments are taken literally,
+// double-quoted ones support backslash escapes.
+func dotQuote(s string) string {
+	if !strings.ContainsAny(s, " \t'\"") {
+		return s
+	}
+	if !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,79 @@
+package sqlpkg
+
+import "testing"
+
+func Test_chooseEntrypoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		entries []string
+		want    string
+	}{
+		{"none", "text.so", nil, ""},
+		{"single", "text.so", []string{"sqlite3_extension_init"}, "sqlite3_extension_init"},
+		{"derived", "libtext0.so", []string{"sqlite3_extension_init", "sqlite3_text_init"}, "sqlite3_text_init"},
+		{"specific", "text.so", []string{"sqlite3_extension_init", "sqlite3_unicode_init"}, "sqlite3_unicode_init"},
+		{"ambiguous", "text.so", []string{"sqlite3_a_init", "sqlite3_b_init"}, "sqlite3_a_init"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := chooseEntrypoint(test.path, test.entries)
+			if got != test.want {
+				t.Errorf("chooseEntrypoint: unexpected value %v", got)
+			}
+		})
+	}
+}
+
+func Test_derivedEntrypoint(t *testing.T) {
+	tests := map[string]string{
+		"text.so":             "sqlite3_text_init",
+		"/tmp/libtext.so.1":   "sqlite3_text_init",
+		"vec0.dylib":          "sqlite3_vec_init",
+		"Stats.dll":           "sqlite3_stats_init",
+		"sqlite-lines-v2.so":  "sqlite3_sqlitelinesv_init",
+		"library/example.dll": "sqlite3_example_init",
+	}
+	for path, want := range tests {
+		if got := derivedEntrypoint(path); got != want {
+			t.Errorf("derivedEntrypoint(%q): unexpected value %v", path, got)
+		}
+	}
+}
+
+func Test_dotQuote(t *testing.T) {
+	tests := map[string]string{
+		"/usr/lib/text.so":        "/usr/lib/text.so",
+		"/Users/Jane Doe/a.so":    "'/Users/Jane Doe/a.so'",
+		`C:\O'Brien\text ext.dll`: `"C:\\O'Brien\\text ext.dll"`,
+	}
+	for s, want := range tests {
+		if got := dotQuote(s); got != want {
+			t.Errorf("dotQuote(%q): unexpected value %v", s, got)
+		}
+	}
+}
+
+func Test_matchPackage(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullName string
+		include  []string
+		exclude  []string
+		want     bool
+	}{
+		{"all", "nalgeon/text", nil, nil, true},
+		{"include", "nalgeon/text", []string{"sqlite/*", "nalgeon/text"}, nil, true},
+		{"not included", "nalgeon/text", []string{"sqlite/*"}, nil, false},
+		{"exclude", "nalgeon/text", nil, []string{"nalgeon/*"}, false},
+		{"exclude wins", "nalgeon/text", []string{"nalgeon/*"}, []string{"nalgeon/text"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := matchPackage(test.fullName, test.include, test.exclude)
+			if got != test.want {
+				t.Errorf("matchPackage: unexpected value %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Test helpers.
+package sqlpkg
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/logx"
+)
+
+// testManager creates a manager for the working directory
+// that logs to stdout and serves HTTP requests
+// from the testdata folder.
+func testManager() *Manager {
+	m := New(".")
+	m.Client = httpx.Mock()
+	m.Logger = logx.NewLogger(os.Stdout)
+	return m
+}
+
+// setupTestRepo deletes the .sqlpkg folder and the lockfile
+// from the working directory and returns a test manager for it.
+func setupTestRepo(t *testing.T) *Manager {
+	m := testManager()
+	err := os.RemoveAll(m.RepoDir())
+	if err != nil {
+		t.Fatalf("setupTestRepo: %v", err)
+	}
+	err = os.RemoveAll(m.LockfilePath())
+	if err != nil {
+		t.Fatalf("setupTestRepo: %v", err)
+	}
+	return m
+}
+
+// copyTestRepo copies the .sqlpkg folder and the lockfile
+// from the testdata folder to the working directory.
+func copyTestRepo(t *testing.T, path ...string) {
+	basePath := filepath.Join("testdata", filepath.Join(path...))
+
+	{
+		// copy `.sqlpkg` contents
+		path := filepath.Join(basePath, ".sqlpkg")
+		cmd := []string{"cp", "-r", path, "."}
+		err := exec.Command(cmd[0], cmd[1:]...).Run()
+		if err != nil {
+			t.Fatalf("%s: %v", strings.Join(cmd, " "), err)
+		}
+	}
+	{
+		// copy lockfile
+		path := filepath.Join(basePath, "sqlpkg.lock")
+		cmd := []string{"cp", path, "."}
+		err := exec.Command(cmd[0], cmd[1:]...).Run()
+		if err != nil {
+			t.Fatalf("%s: %v", strings.Join(cmd, " "), err)
+		}
+	}
+}
+
+// teardownTestRepo deletes the .sqlpkg folder and the lockfile
+// from the working directory.
+func teardownTestRepo(t *testing.T) {
+	m := New(".")
+	err := os.RemoveAll(m.RepoDir())
+	if err != nil {
+		t.Fatalf("teardownTestRepo: %v", err)
+	}
+	err = os.RemoveAll(m.LockfilePath())
+	if err != nil {
+		t.Fatalf("teardownTestRepo: %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Functions that read registry indexes.
+package sqlpkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/spec"
+)
+
+// IndexTTL is how long the downloaded registry index stays fresh.
+const IndexTTL = 24 * time.Hour
+
+// IndexCacheDir returns the directory with downloaded registry indexes.
+func (m *Manager) IndexCacheDir() string {
+	return filepath.Join(m.RepoDir(), ".cache", "index")
+}
+
+// ReadIndex returns package specs from all registries that have an index.
+// If several registries have the same package, the first one wins.
+// Package specs have their Registry set to the registry name.
+func (m *Manager) ReadIndex(ctx context.Context) ([]*spec.Package, error) {
+	registries, err := m.registries()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	packages := []*spec.Package{}
+	for _, reg := range registries {
+		found, err := m.readRegistryIndex(ctx, reg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s registry index: %w", reg.Name, err)
+		}
+		for _, pkg := range found {
+			if seen[pkg.FullName()] {
+				continue
+			}
+			seen[pkg.FullName()] = true
+			pkg.Registry = reg.Name
+			packages = append(packages, pkg)
+		}
+	}
+
+	m.Logger.Debug("read %d packages from registry indexes", len(packages))
+	return packages, nil
+}
+
+// readRegistryIndex returns package specs from the registry index.
+// Scans the registry dir if there is no index.
+func (m *Manager) readRegistryIndex(ctx context.Context, reg spec.Registry) ([]*spec.Package, error) {
+	if reg.Index == "" && reg.Dir != "" {
+		return scanRegistryDir(reg.Dir)
+	}
+	if reg.Index == "" {
+		m.Logger.Debug("registry %s has no index, skipping", reg.Name)
+		return nil, nil
+	}
+
+	var data []byte
+	var err error
+	if httpx.IsURL(reg.Index) {
+		data, err = m.fetchIndex(ctx, reg)
+	} else {
+		data, err = os.ReadFile(reg.Index)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var packages []*spec.Package
+	err = json.Unmarshal(data, &packages)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	m.Logger.Debug("read %d packages from %s", len(packages), reg.Index)
+	return packages, nil
+}
+
+// fetchIndex downloads the remote registry index, or takes it
+// from the cache if it's fresh enough. Falls back to the stale cached index
+// if the download fails.
+func (m *Manager) fetchIndex(ctx context.Context, reg spec.Registry) ([]byte, error) {
+	path := filepath.Join(m.IndexCacheDir(), url.PathEscape(reg.Name)+".json")
+	stat, statErr := os.Stat(path)
+	if statErr == nil && time.Since(stat.ModTime()) < IndexTTL {
+		m.Logger.Debug("using cached index %s", path)
+		return os.ReadFile(path)
+	}
+
+	m.Logger.Debug("downloading index %s", reg.Index)
+	data, err := httpx.GetBytes(ctx, m.Client, reg.Index)
+	if err != nil {
+		if statErr != nil || ctx.Err() != nil {
+			return nil, err
+		}
+		m.Logger.Warn("failed to download %s registry index, using cached one: %s", reg.Name, err)
+		return os.ReadFile(path)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	err = os.WriteFile(path, data, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cache index: %w", err)
+	}
+	return data, nil
+}
+
+// scanRegistryDir reads package specs stored in the registry dir
+// as <owner>/<name>.json.
+func scanRegistryDir(dir string) ([]*spec.Package, error) {
+	paths, _ := filepath.Glob(filepath.Join(dir, "*", "*.json"))
+	packages := make([]*spec.Package, 0, len(paths))
+	for _, path := range paths {
+		pkg, err := spec.ReadLocal(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid package spec: %s", path)
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
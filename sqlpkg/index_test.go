@@ -0,0 +1,129 @@
+package sqlpkg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestManager_ReadIndex(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("registries", func(t *testing.T) {
+		m := setupTestRepo(t)
+		defer teardownTestRepo(t)
+
+		cfg := &config.Config{Registries: []spec.Registry{
+			{Name: "local", Dir: filepath.Join("testdata", "index"), Index: filepath.Join("testdata", "index", "index.json")},
+			{Name: "vetted", Dir: filepath.Join("..", "cmd", "info", "testdata", "registry")},
+			{Name: "remote", URL: "https://example.org/{owner}/{name}.json"},
+		}}
+		err := m.SaveConfig(cfg)
+		if err != nil {
+			t.Fatalf("SaveConfig: unexpected error %v", err)
+		}
+
+		packages, err := m.ReadIndex(ctx)
+		if err != nil {
+			t.Fatalf("ReadIndex: unexpected error %v", err)
+		}
+		if len(packages) != 4 {
+			t.Fatalf("ReadIndex: expected 4 packages, got %d", len(packages))
+		}
+		last := packages[3]
+		if last.FullName() != "nalgeon/example" || last.Registry != "vetted" {
+			t.Errorf("ReadIndex: unexpected package %s from %s", last.FullName(), last.Registry)
+		}
+	})
+	t.Run("duplicates", func(t *testing.T) {
+		m := setupTestRepo(t)
+		defer teardownTestRepo(t)
+
+		path := filepath.Join("testdata", "index", "index.json")
+		cfg := &config.Config{Registries: []spec.Registry{
+			{Name: "first", Dir: "first", Index: path},
+			{Name: "second", Dir: "second", Index: path},
+		}}
+		err := m.SaveConfig(cfg)
+		if err != nil {
+			t.Fatalf("SaveConfig: unexpected error %v", err)
+		}
+
+		packages, err := m.ReadIndex(ctx)
+		if err != nil {
+			t.Fatalf("ReadIndex: unexpected error %v", err)
+		}
+		if len(packages) != 3 {
+			t.Fatalf("ReadIndex: expected 3 packages, got %d", len(packages))
+		}
+		for _, pkg := range packages {
+			if pkg.Registry != "first" {
+				t.Errorf("%s: unexpected registry %q", pkg.FullName(), pkg.Registry)
+			}
+		}
+	})
+}
+
+func TestManager_fetchIndex(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	reg := spec.Registry{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Index: "https://example.org/index.json"}
+	cached := filepath.Join(m.IndexCacheDir(), "internal.json")
+
+	t.Run("download", func(t *testing.T) {
+		m.Client = httpx.Mock("index")
+		data, err := m.fetchIndex(ctx, reg)
+		if err != nil {
+			t.Fatalf("fetchIndex: unexpected error %v", err)
+		}
+		if len(data) == 0 {
+			t.Fatal("fetchIndex: empty index")
+		}
+		if _, err := os.Stat(cached); err != nil {
+			t.Fatalf("fetchIndex: index is not cached: %v", err)
+		}
+	})
+	t.Run("fresh cache", func(t *testing.T) {
+		m.Client = httpx.Mock("missing")
+		_, err := m.fetchIndex(ctx, reg)
+		if err != nil {
+			t.Fatalf("fetchIndex: unexpected error %v", err)
+		}
+	})
+	t.Run("stale cache", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		m.Client = httpx.Mock("missing")
+		old := time.Now().Add(-IndexTTL - time.Hour)
+		err := os.Chtimes(cached, old, old)
+		if err != nil {
+			t.Fatalf("Chtimes: unexpected error %v", err)
+		}
+		data, err := m.fetchIndex(ctx, reg)
+		if err != nil {
+			t.Fatalf("fetchIndex: unexpected error %v", err)
+		}
+		if len(data) == 0 {
+			t.Fatal("fetchIndex: empty index")
+		}
+		mem.MustHave(t, "failed to download internal registry index, using cached one")
+	})
+	t.Run("no cache", func(t *testing.T) {
+		m.Client = httpx.Mock("missing")
+		err := os.RemoveAll(m.IndexCacheDir())
+		if err != nil {
+			t.Fatalf("RemoveAll: unexpected error %v", err)
+		}
+		_, err = m.fetchIndex(ctx, reg)
+		if err == nil {
+			t.Fatal("fetchIndex: expected error, got nil")
+		}
+	})
+}
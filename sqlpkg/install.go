@@ -0,0 +1,273 @@
+// Functions that install, update and uninstall packages.
+package sqlpkg
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/spec"
+)
+
+// InstallOptions configure installs and updates.
+// RequireChecksums fails on assets without a verifiable checksum.
+// It's also enabled by the require_checksums config setting.
+type InstallOptions struct {
+	RequireChecksums bool
+}
+
+// An InstallResult describes an installed package.
+// Installed is false if the package was already at the requested version.
+type InstallResult struct {
+	Package   *spec.Package
+	Dir       string
+	Installed bool
+}
+
+// An UpdateResult describes an updated package.
+// Previous is the version before the update.
+// Updated is false if the package was already at the latest version.
+type UpdateResult struct {
+	Package  *spec.Package
+	Previous string
+	Updated  bool
+}
+
+// Install installs a new package or updates an existing one
+// using a spec file from the given path, which can be an owner-name pair,
+// a GitHub repository, a custom url or a local path.
+func (m *Manager) Install(ctx context.Context, path string, opts InstallOptions) (*InstallResult, error) {
+	pkg, err := m.ReadSpec(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.resolveVersion(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &InstallResult{Package: pkg, Dir: spec.Dir(m.Dir, pkg.Owner, pkg.Name)}
+	if !m.hasNewVersion(pkg) {
+		return res, nil
+	}
+
+	err = m.readChecksums(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.installAsset(ctx, pkg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	lck, err := m.ReadLockfile()
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.addToLockfile(lck, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Installed = true
+	return res, nil
+}
+
+// InstallLocked installs a specific version of a package from the lockfile.
+func (m *Manager) InstallLocked(ctx context.Context, lckPkg *spec.Package, opts InstallOptions) (*InstallResult, error) {
+	path := lckPkg.Specfile
+	if path == "" {
+		m.Logger.Debug("missing specfile for %s, falling back to name/owner", lckPkg.FullName())
+		path = lckPkg.FullName()
+	}
+
+	pkg, err := m.ReadSpec(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// lock the version
+	m.Logger.Debug("locked version = %s", lckPkg.Version)
+	pkg.Version = lckPkg.Version
+	pkg.Assets = lckPkg.Assets
+	if pkg.Registry == "" {
+		// the specfile points inside the registry
+		pkg.Registry = lckPkg.Registry
+	}
+
+	res := &InstallResult{Package: pkg, Dir: spec.Dir(m.Dir, pkg.Owner, pkg.Name)}
+	if !m.hasNewVersion(pkg) {
+		return res, nil
+	}
+
+	err = m.installAsset(ctx, pkg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// no need to add the package to the lockfile,
+	// it's already there
+
+	res.Installed = true
+	return res, nil
+}
+
+// Update updates an installed package to the latest version.
+func (m *Manager) Update(ctx context.Context, fullName string, opts InstallOptions) (*UpdateResult, error) {
+	path, err := m.PackagePath(fullName)
+	if err != nil {
+		return nil, err
+	}
+	if !fileio.Exists(path) {
+		return nil, ErrNotInstalled
+	}
+
+	installed, err := spec.ReadLocal(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid package: %w", err)
+	}
+	m.Logger.Debug("found local spec from %s", path)
+	m.Logger.Debug("read package %s, version = %s", installed.FullName(), installed.Version)
+
+	pkg, err := m.ReadSpec(ctx, specPath(installed))
+	if err != nil {
+		return nil, err
+	}
+	if pkg.Registry == "" {
+		// the specfile points inside the registry
+		pkg.Registry = installed.Registry
+	}
+
+	err = m.resolveVersion(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &UpdateResult{Package: installed, Previous: installed.Version}
+	if !m.hasNewVersion(pkg) {
+		return res, nil
+	}
+
+	err = m.readChecksums(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.installAsset(ctx, pkg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	lck, err := m.ReadLockfile()
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.addToLockfile(lck, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Package = pkg
+	res.Updated = true
+	return res, nil
+}
+
+// Uninstall deletes the installed package and removes it from the lockfile.
+func (m *Manager) Uninstall(fullName string) error {
+	dir, err := m.PackageDir(fullName)
+	if err != nil {
+		return err
+	}
+
+	m.Logger.Debug("checking dir: %s", dir)
+	if !fileio.Exists(dir) {
+		m.Logger.Debug("package dir not found")
+		return ErrNotInstalled
+	}
+
+	m.Logger.Debug("deleting dir: %s", dir)
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return fmt.Errorf("failed to delete package dir: %w", err)
+	}
+	m.Logger.Debug("deleted package dir")
+
+	lck, err := m.ReadLockfile()
+	if err != nil {
+		return err
+	}
+
+	return m.removeFromLockfile(lck, fullName)
+}
+
+// installAsset downloads, verifies and installs the package asset
+// for the manager's platform. Cleans up the staging dir on failure,
+// so that an interrupted install leaves no partial files behind.
+func (m *Manager) installAsset(ctx context.Context, pkg *spec.Package, opts InstallOptions) error {
+	defer m.cleanupStaging(pkg)
+
+	requireChecksums, err := m.requireChecksums(opts.RequireChecksums)
+	if err != nil {
+		return err
+	}
+
+	assetPath, err := m.buildAssetPath(ctx, pkg)
+	if err != nil {
+		return err
+	}
+
+	asset, err := m.downloadAsset(ctx, pkg, assetPath)
+	if err != nil {
+		return err
+	}
+
+	err = m.validateAsset(pkg, asset, requireChecksums)
+	if err != nil {
+		return err
+	}
+
+	err = m.unpackAsset(pkg, asset)
+	if err != nil {
+		return err
+	}
+
+	err = m.validateLibraries(asset)
+	if err != nil {
+		return err
+	}
+	m.warnUnresolved(asset)
+
+	// last chance to stop: installing the files replaces
+	// the existing package and should not be interrupted
+	err = ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	err = m.installFiles(pkg, asset)
+	if err != nil {
+		return err
+	}
+
+	err = m.dequarantineFiles(pkg)
+	if err != nil {
+		return err
+	}
+
+	m.warnConflicts(pkg)
+	return nil
+}
+
+// specPath returns a remote package spec path.
+func specPath(pkg *spec.Package) string {
+	if pkg.Specfile != "" {
+		return pkg.Specfile
+	}
+	// in older specs the .Specfile may be empty
+	return pkg.FullName()
+}
@@ -0,0 +1,200 @@
+package sqlpkg
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestManager_Install(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	t.Run("install", func(t *testing.T) {
+		res, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+		if err != nil {
+			t.Fatalf("Install: unexpected error %v", err)
+		}
+		if !res.Installed {
+			t.Error("Install: expected Installed = true")
+		}
+		if res.Package.FullName() != "nalgeon/example" || res.Package.Version != "0.1.0" {
+			t.Errorf("Install: unexpected package %s@%s", res.Package.FullName(), res.Package.Version)
+		}
+		if res.Dir != spec.Dir(m.Dir, "nalgeon", "example") {
+			t.Errorf("Install: unexpected dir %q", res.Dir)
+		}
+		lck, err := m.ReadLockfile()
+		if err != nil {
+			t.Fatalf("ReadLockfile: unexpected error %v", err)
+		}
+		if !lck.Has("nalgeon/example") {
+			t.Error("Install: package is not in the lockfile")
+		}
+	})
+	t.Run("already installed", func(t *testing.T) {
+		res, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+		if err != nil {
+			t.Fatalf("Install: unexpected error %v", err)
+		}
+		if res.Installed {
+			t.Error("Install: expected Installed = false")
+		}
+	})
+	t.Run("unsupported platform", func(t *testing.T) {
+		m := setupTestRepo(t)
+		m.Platform = Platform{OS: "plan9", Arch: "386"}
+		_, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+		if !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("Install: unexpected error %v", err)
+		}
+	})
+	t.Run("require checksums", func(t *testing.T) {
+		m := setupTestRepo(t)
+		m.Platform = Platform{OS: "linux", Arch: "amd64"}
+		_, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{RequireChecksums: true})
+		if !errors.Is(err, ErrMissingChecksum) {
+			t.Fatalf("Install: unexpected error %v", err)
+		}
+	})
+}
+
+func TestManager_InstallCanceled(t *testing.T) {
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Install: unexpected error %v", err)
+	}
+	if fileio.Exists(m.AssetTempDir()) {
+		t.Error("Install: staging dir is not removed")
+	}
+	if fileio.Exists(spec.Dir(m.Dir, "nalgeon", "example")) {
+		t.Error("Install: package should not be installed")
+	}
+}
+
+func TestManager_Update(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	t.Run("not installed", func(t *testing.T) {
+		_, err := m.Update(ctx, "nalgeon/example", InstallOptions{})
+		if !errors.Is(err, ErrNotInstalled) {
+			t.Fatalf("Update: unexpected error %v", err)
+		}
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := m.Update(ctx, "example", InstallOptions{})
+		if !errors.Is(err, ErrInvalidName) {
+			t.Fatalf("Update: unexpected error %v", err)
+		}
+	})
+}
+
+func TestManager_Uninstall(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	_, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: unexpected error %v", err)
+	}
+
+	t.Run("installed", func(t *testing.T) {
+		err := m.Uninstall("nalgeon/example")
+		if err != nil {
+			t.Fatalf("Uninstall: unexpected error %v", err)
+		}
+		if fileio.Exists(spec.Dir(m.Dir, "nalgeon", "example")) {
+			t.Error("Uninstall: package dir still exists")
+		}
+		lck, _ := m.ReadLockfile()
+		if lck.Has("nalgeon/example") {
+			t.Error("Uninstall: package is still in the lockfile")
+		}
+	})
+	t.Run("not installed", func(t *testing.T) {
+		err := m.Uninstall("nalgeon/example")
+		if !errors.Is(err, ErrNotInstalled) {
+			t.Fatalf("Uninstall: unexpected error %v", err)
+		}
+	})
+}
+
+func TestManager_InstallConflicts(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	other := &spec.Package{Owner: "sqlite", Name: "example", Symbols: []string{"example_fn"}}
+	dir := spec.Dir(m.Dir, other.Owner, other.Name)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		t.Fatalf("MkdirAll: unexpected error %v", err)
+	}
+	err = other.Save(dir)
+	if err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	pkg, err := spec.ReadLocal("testdata/install/sqlpkg.json")
+	if err != nil {
+		t.Fatalf("ReadLocal: unexpected error %v", err)
+	}
+	pkg.Symbols = []string{"example_fn", "example_version"}
+	specDir := t.TempDir()
+	err = pkg.Save(specDir)
+	if err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	mem := logx.Mock(m.Logger)
+	res, err := m.Install(ctx, filepath.Join(specDir, spec.FileName), InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: unexpected error %v", err)
+	}
+	if !res.Installed {
+		t.Error("Install: expected Installed = true")
+	}
+	mem.MustHave(t, "sqlite/example also defines example_fn")
+}
+
+func TestManager_InstallPrivate(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+	m.Client = httpx.Mock("private")
+	t.Setenv("GITHUB_TOKEN", "secret")
+	mem := logx.Mock(m.Logger)
+
+	res, err := m.Install(ctx, "testdata/private/sqlpkg.json", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: unexpected error %v", err)
+	}
+	if !res.Installed {
+		t.Error("Install: expected Installed = true")
+	}
+	mem.MustHave(t, "using release asset api url")
+	mem.MustHave(t, "downloaded example-linux-0.1.0-x86.zip")
+}
@@ -0,0 +1,155 @@
+// Functions that inspect extension library files.
+package sqlpkg
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"sqlpkg.org/cli/assets"
+	"sqlpkg.org/cli/binfile"
+	"sqlpkg.org/cli/spec"
+)
+
+// validateLibraries checks that the unpacked library files
+// can be loaded on the manager's platform.
+func (m *Manager) validateLibraries(asset *assets.Asset) error {
+	problems, err := m.CheckLibraries(asset.Dir(), m.Platform)
+	if err != nil {
+		return err
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("incompatible library files: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// CheckLibraries checks the format and architecture of library files
+// in the dir against the platform. Returns a problem description
+// for each incompatible file. Skips files that are not binaries.
+func (m *Manager) CheckLibraries(dir string, platform Platform) ([]string, error) {
+	paths, err := binfile.Find(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find library files: %w", err)
+	}
+
+	problems := []string{}
+	for _, path := range paths {
+		name, _ := filepath.Rel(dir, path)
+		info, err := binfile.Inspect(path)
+		if errors.Is(err, binfile.ErrUnknownFormat) {
+			m.Logger.Debug("not a binary file, skipping: %s", name)
+			continue
+		}
+		if err == nil {
+			err = info.Check(platform.OS, platform.Arch)
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+		m.Logger.Debug("library %s matches the platform %s", name, platform)
+	}
+	return problems, nil
+}
+
+// findEntrypoints discovers extension entry points exported by
+// the library files in the package dir and records them in the spec.
+// Fills the package symbols with the entry points if they are empty.
+func (m *Manager) findEntrypoints(pkg *spec.Package, dir string) error {
+	paths, err := binfile.Find(dir)
+	if err != nil {
+		return fmt.Errorf("failed to find library files: %w", err)
+	}
+
+	found := map[string][]string{}
+	for _, path := range paths {
+		entries, err := binfile.EntryPoints(path)
+		if errors.Is(err, binfile.ErrUnknownFormat) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read entry points: %w", err)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		name, _ := filepath.Rel(dir, path)
+		found[filepath.ToSlash(name)] = entries
+	}
+
+	if len(found) == 0 {
+		pkg.Entrypoints = nil
+		m.Logger.Debug("no entry points found")
+		return nil
+	}
+	pkg.Entrypoints = found
+	m.Logger.Debug("found entry points in %d files", len(found))
+
+	if len(pkg.Symbols) == 0 {
+		pkg.Symbols = allEntrypoints(found)
+	}
+	return nil
+}
+
+// allEntrypoints returns unique entry points from all files, sorted by name.
+func allEntrypoints(found map[string][]string) []string {
+	seen := map[string]bool{}
+	all := []string{}
+	for _, entries := range found {
+		for _, entry := range entries {
+			if !seen[entry] {
+				seen[entry] = true
+				all = append(all, entry)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// warnUnresolved prints a warning for each shared library
+// required by the unpacked library files that can't be found on the host.
+// Does not fail the install if the check itself fails. Skips the check
+// when installing for another platform, since the host's libraries
+// say nothing about the target system.
+func (m *Manager) warnUnresolved(asset *assets.Asset) {
+	if m.Platform != CurrentPlatform() {
+		m.Logger.Debug("skipping library dependency check for %s", m.Platform)
+		return
+	}
+	problems, err := FindUnresolved(asset.Dir(), binfile.DefaultHost())
+	if err != nil {
+		m.Logger.Warn("failed to check library dependencies: %s", err)
+		return
+	}
+	for _, problem := range problems {
+		m.Logger.Warn("%s", problem)
+	}
+}
+
+// FindUnresolved checks the dependencies of library files in the dir.
+// Returns a problem description for each dependency
+// that can't be resolved on the host.
+func FindUnresolved(dir string, host *binfile.Host) ([]string, error) {
+	paths, err := binfile.Find(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find library files: %w", err)
+	}
+
+	problems := []string{}
+	for _, path := range paths {
+		name, _ := filepath.Rel(dir, path)
+		missing, err := host.Unresolved(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: failed to read dependencies: %s", name, err))
+			continue
+		}
+		for _, m := range missing {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, m))
+		}
+	}
+	return problems, nil
+}
@@ -0,0 +1,158 @@
+package sqlpkg
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/assets"
+	"sqlpkg.org/cli/binfile"
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestValidateLibraries(t *testing.T) {
+	m := testManager()
+	t.Run("valid", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		asset := copyLibrary(t, "text.so")
+		err := m.validateLibraries(asset)
+		if err != nil {
+			t.Fatalf("ValidateLibraries: unexpected error %v", err)
+		}
+		mem.MustHave(t, "not a binary file, skipping: text.so")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		asset := copyLibrary(t, "sparc.so")
+		err := m.validateLibraries(asset)
+		if err == nil {
+			t.Fatal("ValidateLibraries: expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "incompatible library files: sparc.so") {
+			t.Errorf("ValidateLibraries: unexpected error %v", err)
+		}
+	})
+}
+
+func TestWarnUnresolved(t *testing.T) {
+	m := testManager()
+	t.Run("other platform", func(t *testing.T) {
+		m.Platform = Platform{OS: "plan9", Arch: "386"}
+		defer func() { m.Platform = CurrentPlatform() }()
+		mem := logx.Mock(m.Logger)
+		asset := copyLibrary(t, "sparc.so")
+		m.warnUnresolved(asset)
+		mem.MustHave(t, "skipping library dependency check for plan9-386")
+	})
+}
+
+func TestCheckLibraries(t *testing.T) {
+	m := testManager()
+	logx.Mock(m.Logger)
+	dir := filepath.Join("testdata", "libs")
+
+	t.Run("darwin-arm64", func(t *testing.T) {
+		problems, err := m.CheckLibraries(dir, Platform{"darwin", "arm64"})
+		if err != nil {
+			t.Fatalf("CheckLibraries: unexpected error %v", err)
+		}
+		want := []string{"sparc.so: library does not match the platform: ELF file, want Mach-O"}
+		if !reflect.DeepEqual(problems, want) {
+			t.Errorf("CheckLibraries: unexpected problems %v", problems)
+		}
+	})
+	t.Run("darwin-amd64", func(t *testing.T) {
+		problems, err := m.CheckLibraries(dir, Platform{"darwin", "amd64"})
+		if err != nil {
+			t.Fatalf("CheckLibraries: unexpected error %v", err)
+		}
+		want := []string{
+			"example.dylib: library does not match the platform: built for arm64 (64-bit), want amd64 (64-bit)",
+			"sparc.so: library does not match the platform: ELF file, want Mach-O",
+		}
+		if !reflect.DeepEqual(problems, want) {
+			t.Errorf("CheckLibraries: unexpected problems %v", problems)
+		}
+	})
+}
+
+// copyLibrary copies the library file from testdata/libs
+// to a temporary directory and returns it as an asset.
+func copyLibrary(t *testing.T, name string) *assets.Asset {
+	path := filepath.Join(t.TempDir(), name)
+	_, err := fileio.CopyFile(filepath.Join("testdata", "libs", name), path)
+	if err != nil {
+		t.Fatalf("fileio.CopyFile: unexpected error %v", err)
+	}
+	return &assets.Asset{Name: name, Path: path}
+}
+
+func TestFindEntrypoints(t *testing.T) {
+	m := testManager()
+	t.Run("found", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		asset := copyLibrary(t, "example.dylib")
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example"}
+		err := m.findEntrypoints(pkg, asset.Dir())
+		if err != nil {
+			t.Fatalf("FindEntrypoints: unexpected error %v", err)
+		}
+		want := map[string][]string{"example.dylib": {"sqlite3_example_init"}}
+		if !reflect.DeepEqual(pkg.Entrypoints, want) {
+			t.Errorf("FindEntrypoints: unexpected entry points %v", pkg.Entrypoints)
+		}
+		if !reflect.DeepEqual(pkg.Symbols, []string{"sqlite3_example_init"}) {
+			t.Errorf("FindEntrypoints: unexpected symbols %v", pkg.Symbols)
+		}
+	})
+	t.Run("keep symbols", func(t *testing.T) {
+		logx.Mock(m.Logger)
+		asset := copyLibrary(t, "example.dylib")
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Symbols: []string{"example"}}
+		err := m.findEntrypoints(pkg, asset.Dir())
+		if err != nil {
+			t.Fatalf("FindEntrypoints: unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(pkg.Symbols, []string{"example"}) {
+			t.Errorf("FindEntrypoints: unexpected symbols %v", pkg.Symbols)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		asset := copyLibrary(t, "text.so")
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example"}
+		err := m.findEntrypoints(pkg, asset.Dir())
+		if err != nil {
+			t.Fatalf("FindEntrypoints: unexpected error %v", err)
+		}
+		if pkg.Entrypoints != nil {
+			t.Errorf("FindEntrypoints: unexpected entry points %v", pkg.Entrypoints)
+		}
+		mem.MustHave(t, "no entry points found")
+	})
+}
+
+func TestFindUnresolved(t *testing.T) {
+	asset := copyLibrary(t, "example.dylib")
+	host := &binfile.Host{GOOS: "darwin"}
+	problems, err := FindUnresolved(asset.Dir(), host)
+	if err != nil {
+		t.Fatalf("FindUnresolved: unexpected error %v", err)
+	}
+	want := []string{"example.dylib: library @loader_path/libicuuc.74.dylib is not found"}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("FindUnresolved: unexpected problems %v", problems)
+	}
+
+	host = &binfile.Host{GOOS: "linux"}
+	problems, err = FindUnresolved(asset.Dir(), host)
+	if err != nil {
+		t.Fatalf("FindUnresolved: unexpected error %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("FindUnresolved: unexpected problems %v", problems)
+	}
+}
@@ -0,0 +1,76 @@
+// Functions that manage the init script that loads installed extensions.
+package sqlpkg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sqlpkg.org/cli/config"
+)
+
+// LoaderFileName is the default init script filename.
+const LoaderFileName = "init.sql"
+
+const loaderHeader = `-- Loads SQLite extensions installed with sqlpkg.
+-- Generated by 'sqlpkg loader', do not edit by hand.
+`
+
+// LoaderPath returns the path to the init script.
+// Uses the default path if the output is not set.
+func (m *Manager) LoaderPath(ldr *config.Loader) string {
+	if ldr.Output != "" {
+		return ldr.Output
+	}
+	return filepath.Join(m.RepoDir(), LoaderFileName)
+}
+
+// WriteLoader writes the sqlite3 init script that loads extensions
+// of the installed packages selected by the loader settings.
+// Packages are loaded in the order of their full names.
+// Returns the number of extensions in the script.
+func (m *Manager) WriteLoader(ldr *config.Loader) (int, error) {
+	exts, err := m.SelectExtensions(ldr.Include, ldr.Exclude)
+	if err != nil {
+		return 0, err
+	}
+
+	var b strings.Builder
+	b.WriteString(loaderHeader)
+	for _, ext := range exts {
+		b.WriteString(ext.LoadCommand())
+		b.WriteString("\n")
+	}
+
+	path := m.LoaderPath(ldr)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write init script: %w", err)
+	}
+	err = os.WriteFile(path, []byte(b.String()), 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write init script: %w", err)
+	}
+
+	m.Logger.Debug("wrote %d extensions to %s", len(exts), path)
+	return len(exts), nil
+}
+
+// RegenerateLoader rewrites the init script
+// if automatic updates are enabled in the config.
+func (m *Manager) RegenerateLoader() error {
+	cfg, err := m.ReadConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.Loader == nil || !cfg.Loader.Auto {
+		return nil
+	}
+	_, err = m.WriteLoader(cfg.Loader)
+	if err != nil {
+		return fmt.Errorf("failed to update init script: %w", err)
+	}
+	m.Logger.Log("✓ updated init script %s", m.LoaderPath(cfg.Loader))
+	return nil
+}
@@ -0,0 +1,65 @@
+// Functions that manage the lockfile.
+package sqlpkg
+
+import (
+	"fmt"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/lockfile"
+	"sqlpkg.org/cli/spec"
+)
+
+// ReadLockfile reads lockfile from the work directory.
+func (m *Manager) ReadLockfile() (*lockfile.Lockfile, error) {
+	path := lockfile.Path(m.Dir)
+	if !fileio.Exists(path) {
+		m.Logger.Debug("created new lockfile")
+		return lockfile.NewLockfile(), nil
+	}
+
+	lck, err := lockfile.ReadLocal(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	m.Logger.Debug("read existing lockfile")
+	return lck, nil
+}
+
+// addToLockfile adds package to the lockfile.
+// Keeps the package key pinned even if the spec no longer has it.
+func (m *Manager) addToLockfile(lck *lockfile.Lockfile, pkg *spec.Package) error {
+	var pinned string
+	if lckPkg, ok := lck.Packages[pkg.FullName()]; ok {
+		pinned = lckPkg.Publickey
+	}
+	lck.Add(pkg)
+	if pkg.Publickey == "" && pinned != "" {
+		lck.Packages[pkg.FullName()].Publickey = pinned
+	}
+	err := lck.Save(m.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	m.Logger.Debug("added package to the lockfile")
+	return nil
+}
+
+// removeFromLockfile removes package from the lockfile.
+func (m *Manager) removeFromLockfile(lck *lockfile.Lockfile, fullName string) error {
+	pkg, ok := lck.Packages[fullName]
+	if !ok {
+		m.Logger.Debug("package not listed in the lockfile")
+		return nil
+	}
+
+	lck.Remove(pkg)
+	err := lck.Save(m.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to save lockfile: %w", err)
+	}
+
+	m.Logger.Debug("removed package from the lockfile")
+	return nil
+}
@@ -1,4 +1,4 @@
-package cmd
+package sqlpkg
 
 import (
 	"os"
@@ -8,12 +8,13 @@ import (
 )
 
 func TestReadLockfile(t *testing.T) {
+	m := testManager()
 	t.Run("existing", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
-		CopyTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
 
-		lck, err := ReadLockfile()
+		lck, err := m.ReadLockfile()
 		if err != nil {
 			t.Fatalf("ReadLockfile: unexpected error %v", err)
 		}
@@ -22,10 +23,10 @@ func TestReadLockfile(t *testing.T) {
 		}
 	})
 	t.Run("new", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
 
-		lck, err := ReadLockfile()
+		lck, err := m.ReadLockfile()
 		if err != nil {
 			t.Fatalf("ReadLockfile: unexpected error %v", err)
 		}
@@ -34,14 +35,15 @@ func TestReadLockfile(t *testing.T) {
 		}
 	})
 	t.Run("invalid", func(t *testing.T) {
-		_, lockPath := SetupTestRepo(t)
-		defer TeardownTestRepo(t)
+		setupTestRepo(t)
+		lockPath := m.LockfilePath()
+		defer teardownTestRepo(t)
 		err := os.WriteFile(lockPath, []byte("invalid"), 0644)
 		if err != nil {
 			t.Fatalf("os.WriteFile: %v", err)
 		}
 
-		_, err = ReadLockfile()
+		_, err = m.ReadLockfile()
 		if err == nil {
 			t.Fatal("ReadLockfile: expected error, got nil")
 		}
@@ -49,22 +51,23 @@ func TestReadLockfile(t *testing.T) {
 }
 
 func TestAddToLockfile(t *testing.T) {
-	SetupTestRepo(t)
-	defer TeardownTestRepo(t)
-	CopyTestRepo(t)
+	m := testManager()
+	setupTestRepo(t)
+	defer teardownTestRepo(t)
+	copyTestRepo(t)
 
-	lck, err := ReadLockfile()
+	lck, err := m.ReadLockfile()
 	if err != nil {
 		t.Fatalf("ReadLockfile: %v", err)
 	}
 
 	pkg := &spec.Package{Owner: "nalgeon", Name: "text", Version: "0.5.0"}
-	err = AddToLockfile(lck, pkg)
+	err = m.addToLockfile(lck, pkg)
 	if err != nil {
 		t.Fatalf("AddToLockfile: unexpected error %v", err)
 	}
 
-	lck, err = ReadLockfile()
+	lck, err = m.ReadLockfile()
 	if err != nil {
 		t.Fatalf("ReadLockfile: %v", err)
 	}
@@ -78,22 +81,23 @@ func TestAddToLockfile(t *testing.T) {
 }
 
 func TestRemoveFromLockfile(t *testing.T) {
+	m := testManager()
 	t.Run("existing", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
-		CopyTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
 
-		lck, err := ReadLockfile()
+		lck, err := m.ReadLockfile()
 		if err != nil {
 			t.Fatalf("ReadLockfile: %v", err)
 		}
 
-		err = RemoveFromLockfile(lck, "nalgeon/example")
+		err = m.removeFromLockfile(lck, "nalgeon/example")
 		if err != nil {
 			t.Fatalf("RemoveFromLockfile: unexpected error %v", err)
 		}
 
-		lck, err = ReadLockfile()
+		lck, err = m.ReadLockfile()
 		if err != nil {
 			t.Fatalf("ReadLockfile: %v", err)
 		}
@@ -106,21 +110,21 @@ func TestRemoveFromLockfile(t *testing.T) {
 		}
 	})
 	t.Run("not founr", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
-		CopyTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
 
-		lck, err := ReadLockfile()
+		lck, err := m.ReadLockfile()
 		if err != nil {
 			t.Fatalf("ReadLockfile: %v", err)
 		}
 
-		err = RemoveFromLockfile(lck, "nalgeon/missing")
+		err = m.removeFromLockfile(lck, "nalgeon/missing")
 		if err != nil {
 			t.Fatalf("RemoveFromLockfile: unexpected error %v", err)
 		}
 
-		lck, err = ReadLockfile()
+		lck, err = m.ReadLockfile()
 		if err != nil {
 			t.Fatalf("ReadLockfile: %v", err)
 		}
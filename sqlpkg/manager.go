@@ -0,0 +1,129 @@
+// Package sqlpkg installs, updates and locates SQLite extension packages.
+//
+// A Manager works with packages in a single scope: a root dir that contains
+// the .sqlpkg folder with installed packages and the sqlpkg.lock lockfile.
+// Managers do not share state, so a program can work with several scopes
+// at once.
+package sqlpkg
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/lockfile"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+// Errors returned by the Manager.
+var (
+	ErrInvalidName       = errors.New("invalid package name")
+	ErrNotInstalled      = errors.New("package is not installed")
+	ErrExtensionNotFound = errors.New("extension file is not found")
+	ErrUnsupported       = errors.New("unsupported platform")
+	ErrMissingChecksum   = errors.New("spec is missing asset checksum (checksums are required)")
+	ErrInvalidChecksum   = errors.New("asset checksum is invalid")
+)
+
+// A Platform is an operating system and a CPU architecture
+// in Go terms (e.g. darwin and arm64).
+type Platform struct {
+	OS   string
+	Arch string
+}
+
+// CurrentPlatform returns the platform the program is running on.
+func CurrentPlatform() Platform {
+	return Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+}
+
+// String returns the platform as it appears in package specs (e.g. darwin-arm64).
+func (p Platform) String() string {
+	return p.OS + "-" + p.Arch
+}
+
+// A Manager manages packages in the root dir.
+// Client sends HTTP requests, Logger prints progress and debug messages,
+// and Platform selects the package assets to install.
+type Manager struct {
+	Dir      string
+	Client   httpx.Client
+	Logger   *logx.Logger
+	Platform Platform
+}
+
+// New creates a manager for the root dir with the default HTTP client,
+// a logger that discards all messages, and the current platform.
+// The default client caches responses in the .sqlpkg folder and
+// authenticates requests with the tokens or credential helpers
+// from the config, the GitHub token from the environment,
+// or the netrc credentials. It also reports the API rate limit quota
+// as debug messages.
+func New(dir string) *Manager {
+	m := &Manager{
+		Dir:      dir,
+		Logger:   logx.NewLogger(io.Discard),
+		Platform: CurrentPlatform(),
+	}
+	debug := func(message string, args ...any) {
+		m.Logger.Debug(message, args...)
+	}
+	quota := &httpx.RateLimitClient{Client: httpx.NewClient(), Debug: debug}
+	auth := httpx.NewAuthClient(
+		httpx.NewCacheClient(quota, m.HTTPCacheDir()),
+		httpx.HostTokens(sync.OnceValues(m.hostTokens), "config"),
+		httpx.HelperCredentials(sync.OnceValues(m.credentialHelpers)),
+		httpx.GitHubCredentials,
+		httpx.Netrc(httpx.NetrcPath()),
+	)
+	auth.Debug = debug
+	m.Client = auth
+	return m
+}
+
+// RepoDir returns the path to the folder with installed packages.
+func (m *Manager) RepoDir() string {
+	return filepath.Join(m.Dir, spec.DirName)
+}
+
+// HTTPCacheDir returns the directory with cached HTTP responses.
+func (m *Manager) HTTPCacheDir() string {
+	return filepath.Join(m.RepoDir(), ".cache", "http")
+}
+
+// LockfilePath returns the path to the lockfile.
+func (m *Manager) LockfilePath() string {
+	return lockfile.Path(m.Dir)
+}
+
+// PackageDir expands an owner-name package pair to a full package dir.
+func (m *Manager) PackageDir(fullName string) (string, error) {
+	owner, name, err := splitName(fullName)
+	if err != nil {
+		return "", err
+	}
+	return spec.Dir(m.Dir, owner, name), nil
+}
+
+// PackagePath expands an owner-name package pair to a full sqlpkg.json path.
+func (m *Manager) PackagePath(fullName string) (string, error) {
+	owner, name, err := splitName(fullName)
+	if err != nil {
+		return "", err
+	}
+	return spec.Path(m.Dir, owner, name), nil
+}
+
+// splitName splits the full package name into owner and name.
+func splitName(fullName string) (owner, name string, err error) {
+	parts := strings.Split(fullName, "/")
+	if len(parts) != 2 {
+		return "", "", ErrInvalidName
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,54 @@
+package sqlpkg
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"sqlpkg.org/cli/spec"
+)
+
+func TestPlatform(t *testing.T) {
+	p := Platform{OS: "darwin", Arch: "arm64"}
+	if p.String() != "darwin-arm64" {
+		t.Errorf("Platform.String: unexpected value %q", p.String())
+	}
+}
+
+func TestManager_PackagePath(t *testing.T) {
+	m := New("/tmp")
+	t.Run("valid", func(t *testing.T) {
+		path, err := m.PackagePath("nalgeon/example")
+		if err != nil {
+			t.Fatalf("PackagePath: unexpected error %v", err)
+		}
+		if path != filepath.Join("/tmp", spec.DirName, "nalgeon", "example", spec.FileName) {
+			t.Errorf("PackagePath: unexpected value %q", path)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := m.PackagePath("nalgeon")
+		if !errors.Is(err, ErrInvalidName) {
+			t.Fatalf("PackagePath: unexpected error %v", err)
+		}
+	})
+}
+
+func TestManager_PackageDir(t *testing.T) {
+	m := New("/tmp")
+	t.Run("valid", func(t *testing.T) {
+		dir, err := m.PackageDir("nalgeon/example")
+		if err != nil {
+			t.Fatalf("PackageDir: unexpected error %v", err)
+		}
+		if dir != filepath.Join("/tmp", spec.DirName, "nalgeon", "example") {
+			t.Errorf("PackageDir: unexpected value %q", dir)
+		}
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := m.PackageDir("nalgeon")
+		if !errors.Is(err, ErrInvalidName) {
+			t.Fatalf("PackageDir: unexpected error %v", err)
+		}
+	})
+}
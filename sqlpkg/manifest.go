@@ -0,0 +1,52 @@
+// Functions that manage package manifests.
+package sqlpkg
+
+import (
+	"errors"
+	"fmt"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/manifest"
+	"sqlpkg.org/cli/spec"
+)
+
+// ErrMissingManifest means that the installed package has no manifest
+// (e.g. it was installed by an older sqlpkg version).
+var ErrMissingManifest = errors.New("package manifest is missing")
+
+// writeManifest writes the manifest of installed package files.
+func (m *Manager) writeManifest(pkg *spec.Package) error {
+	pkgDir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+	mf, err := manifest.Build(pkgDir)
+	if err != nil {
+		return fmt.Errorf("failed to build package manifest: %w", err)
+	}
+	err = mf.Save(pkgDir)
+	if err != nil {
+		return fmt.Errorf("failed to write package manifest: %w", err)
+	}
+	m.Logger.Debug("wrote manifest with %d files", len(mf.Files))
+	return nil
+}
+
+// VerifyFiles checks installed package files against the package manifest.
+func (m *Manager) VerifyFiles(pkg *spec.Package) (*manifest.Report, error) {
+	pkgDir := spec.Dir(m.Dir, pkg.Owner, pkg.Name)
+	path := manifest.Path(pkgDir)
+	if !fileio.Exists(path) {
+		return nil, ErrMissingManifest
+	}
+
+	mf, err := manifest.ReadLocal(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package manifest: %w", err)
+	}
+
+	report, err := mf.Verify(pkgDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify package files: %w", err)
+	}
+
+	m.Logger.Debug("verified %d files", len(mf.Files))
+	return report, nil
+}
@@ -0,0 +1,61 @@
+// Functions that describe packages.
+package sqlpkg
+
+import (
+	"context"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/spec"
+)
+
+// An InfoResult describes a package, installed or not.
+type InfoResult struct {
+	Package   *spec.Package
+	Installed bool
+}
+
+// A WhichResult describes extension files of an installed package.
+// If there is a file named after the package, Exact is true
+// and Extensions has only this file. Otherwise, Extensions has
+// all the files with the platform's library extension.
+type WhichResult struct {
+	Package    *spec.Package
+	Extensions []Extension
+	Exact      bool
+}
+
+// Info returns the package spec, giving preference to the installed package.
+// The path is the same as for Install.
+func (m *Manager) Info(ctx context.Context, path string) (*InfoResult, error) {
+	pkg, err := m.FindSpec(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	installPath := spec.Path(m.Dir, pkg.Owner, pkg.Name)
+	return &InfoResult{Package: pkg, Installed: fileio.Exists(installPath)}, nil
+}
+
+// Which returns extension files of the installed package
+// for the manager's platform.
+func (m *Manager) Which(fullName string) (*WhichResult, error) {
+	owner, name, err := splitName(fullName)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgDir := spec.Dir(m.Dir, owner, name)
+	if !fileio.Exists(pkgDir) {
+		return nil, ErrNotInstalled
+	}
+
+	pkg := m.ReadInstalledSpec(fullName)
+	if pkg == nil {
+		pkg = &spec.Package{Owner: owner, Name: name}
+	}
+
+	paths, exact := FindExtensionFiles(pkgDir, name, m.Platform.OS)
+	if len(paths) == 0 {
+		return nil, ErrExtensionNotFound
+	}
+	return &WhichResult{Package: pkg, Extensions: m.describeFiles(pkg, paths), Exact: exact}, nil
+}
@@ -0,0 +1,77 @@
+package sqlpkg
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestManager_Info(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	res, err := m.Info(ctx, "testdata/install/sqlpkg.json")
+	if err != nil {
+		t.Fatalf("Info: unexpected error %v", err)
+	}
+	if res.Installed {
+		t.Error("Info: expected Installed = false")
+	}
+	if res.Package.Description != "Example extension." {
+		t.Errorf("Info: unexpected description %q", res.Package.Description)
+	}
+
+	_, err = m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install: unexpected error %v", err)
+	}
+	res, err = m.Info(ctx, "nalgeon/example")
+	if err != nil {
+		t.Fatalf("Info: unexpected error %v", err)
+	}
+	if !res.Installed {
+		t.Error("Info: expected Installed = true")
+	}
+}
+
+func TestManager_Which(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Platform = Platform{OS: "linux", Arch: "amd64"}
+
+	t.Run("not installed", func(t *testing.T) {
+		_, err := m.Which("nalgeon/example")
+		if !errors.Is(err, ErrNotInstalled) {
+			t.Fatalf("Which: unexpected error %v", err)
+		}
+	})
+	t.Run("installed", func(t *testing.T) {
+		_, err := m.Install(ctx, "testdata/install/sqlpkg.json", InstallOptions{})
+		if err != nil {
+			t.Fatalf("Install: unexpected error %v", err)
+		}
+		res, err := m.Which("nalgeon/example")
+		if err != nil {
+			t.Fatalf("Which: unexpected error %v", err)
+		}
+		if !res.Exact || len(res.Extensions) != 1 {
+			t.Fatalf("Which: unexpected result %+v", res)
+		}
+		want := filepath.Join(m.RepoDir(), "nalgeon", "example", "example.so")
+		if res.Extensions[0].Path != want {
+			t.Errorf("Which: unexpected path %q", res.Extensions[0].Path)
+		}
+	})
+	t.Run("other platform", func(t *testing.T) {
+		m := testManager()
+		m.Platform = Platform{OS: "darwin", Arch: "arm64"}
+		_, err := m.Which("nalgeon/example")
+		if !errors.Is(err, ErrExtensionNotFound) {
+			t.Fatalf("Which: unexpected error %v", err)
+		}
+	})
+}
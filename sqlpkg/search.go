@@ -0,0 +1,175 @@
+// Functions that search for packages.
+package sqlpkg
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"sqlpkg.org/cli/spec"
+)
+
+// A SearchQuery selects packages from the registry index.
+// Every term should match the package name, description, keywords or symbols.
+// Keyword and Owner, if set, should match exactly (case-insensitive).
+type SearchQuery struct {
+	Terms   []string
+	Keyword string
+	Owner   string
+}
+
+// A SearchResult is a package that matches the query.
+// Packages with a higher Score are better matches.
+type SearchResult struct {
+	Package *spec.Package
+	Score   int
+}
+
+// Search returns packages from the registry index that match the query,
+// best matches first.
+func (m *Manager) Search(ctx context.Context, query SearchQuery) ([]SearchResult, error) {
+	packages, err := m.ReadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []SearchResult{}
+	for _, pkg := range packages {
+		if !matchFilters(pkg, query) {
+			continue
+		}
+		score, ok := rankPackage(pkg, query.Terms)
+		if !ok {
+			continue
+		}
+		results = append(results, SearchResult{Package: pkg, Score: score})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Package.FullName() < results[j].Package.FullName()
+	})
+	m.Logger.Debug("found %d matching packages", len(results))
+	return results, nil
+}
+
+// matchFilters checks if the package has the query keyword and owner.
+func matchFilters(pkg *spec.Package, query SearchQuery) bool {
+	if query.Owner != "" && !strings.EqualFold(pkg.Owner, query.Owner) {
+		return false
+	}
+	if query.Keyword == "" {
+		return true
+	}
+	for _, keyword := range pkg.Keywords {
+		if strings.EqualFold(keyword, query.Keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankPackage scores the package against the search terms.
+// Returns false if any of the terms does not match the package.
+func rankPackage(pkg *spec.Package, terms []string) (int, bool) {
+	total := 0
+	for _, term := range terms {
+		score := rankTerm(pkg, strings.ToLower(term))
+		if score == 0 {
+			return 0, false
+		}
+		total += score
+	}
+	return total, true
+}
+
+// rankTerm scores the package against a single lowercase search term.
+// Name matches are worth the most, description matches the least.
+func rankTerm(pkg *spec.Package, term string) int {
+	score := 0
+	name := strings.ToLower(pkg.Name)
+	switch {
+	case name == term:
+		score += 10
+	case strings.Contains(name, term):
+		score += 5
+	}
+	score += rankList(pkg.Keywords, term, 4)
+	score += rankList(pkg.Symbols, term, 4)
+	if strings.Contains(strings.ToLower(pkg.Description), term) {
+		score += 1
+	}
+	return score
+}
+
+// rankList scores the best match of the term in the list:
+// full weight for the exact match, half the weight for a partial one.
+func rankList(list []string, term string, weight int) int {
+	best := 0
+	for _, item := range list {
+		item = strings.ToLower(item)
+		if item == term {
+			return weight
+		}
+		if strings.Contains(item, term) {
+			best = weight / 2
+		}
+	}
+	return best
+}
+
+// A ProvidesResult is a package that defines the symbol.
+type ProvidesResult struct {
+	Package   *spec.Package
+	Installed bool
+}
+
+// Provides returns packages that define the SQL symbol (e.g. a function),
+// installed packages first, then the ones from the registry index.
+// Symbols are case-insensitive, as SQL function names are. If the registry
+// index is not available, returns only the installed packages.
+func (m *Manager) Provides(ctx context.Context, symbol string) ([]ProvidesResult, error) {
+	installed, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	results := []ProvidesResult{}
+	seen := map[string]bool{}
+	for _, pkg := range installed {
+		seen[pkg.FullName()] = true
+		if hasSymbol(pkg, symbol) {
+			results = append(results, ProvidesResult{Package: pkg, Installed: true})
+		}
+	}
+
+	indexed, err := m.ReadIndex(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		m.Logger.Warn("failed to read registry index, using installed packages only: %s", err)
+		indexed = nil
+	}
+	for _, pkg := range indexed {
+		if seen[pkg.FullName()] || !hasSymbol(pkg, symbol) {
+			continue
+		}
+		results = append(results, ProvidesResult{Package: pkg})
+	}
+
+	m.Logger.Debug("found %d packages that provide %s", len(results), symbol)
+	return results, nil
+}
+
+// hasSymbol checks if the package defines the symbol.
+func hasSymbol(pkg *spec.Package, symbol string) bool {
+	for _, sym := range pkg.Symbols {
+		if strings.EqualFold(sym, symbol) {
+			return true
+		}
+	}
+	return false
+}
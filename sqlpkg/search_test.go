@@ -0,0 +1,144 @@
+package sqlpkg
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/logx"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestManager_Search(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	m.Client = httpx.Mock("index")
+
+	cfg := &config.Config{Registries: []spec.Registry{
+		{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Index: "https://example.org/index.json"},
+	}}
+	err := m.SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig: unexpected error %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  []string
+	}{
+		{"name", SearchQuery{Terms: []string{"stats"}}, []string{"nalgeon/stats"}},
+		{"keyword", SearchQuery{Terms: []string{"math"}}, []string{"nalgeon/math", "nalgeon/stats"}},
+		{"symbol", SearchQuery{Terms: []string{"median"}}, []string{"nalgeon/stats", "asg017/vec"}},
+		{"all terms", SearchQuery{Terms: []string{"median", "vector"}}, []string{"asg017/vec"}},
+		{"case", SearchQuery{Terms: []string{"SQRT"}}, []string{"nalgeon/math"}},
+		{"owner", SearchQuery{Owner: "asg017"}, []string{"asg017/vec"}},
+		{"keyword filter", SearchQuery{Terms: []string{"median"}, Keyword: "math"}, []string{"nalgeon/stats"}},
+		{"not found", SearchQuery{Terms: []string{"crypto"}}, []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results, err := m.Search(ctx, test.query)
+			if err != nil {
+				t.Fatalf("Search: unexpected error %v", err)
+			}
+			got := []string{}
+			for _, res := range results {
+				got = append(got, res.Package.FullName())
+				if res.Package.Registry != "internal" {
+					t.Errorf("%s: unexpected registry %q", res.Package.FullName(), res.Package.Registry)
+				}
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Search: expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func Test_rankTerm(t *testing.T) {
+	pkg := &spec.Package{
+		Name:        "stats",
+		Description: "Statistics functions.",
+		Keywords:    []string{"math", "statistics"},
+		Symbols:     []string{"median", "percentile"},
+	}
+	tests := []struct {
+		term string
+		want int
+	}{
+		{"stats", 10},
+		{"stat", 5 + 2 + 1},
+		{"math", 4},
+		{"median", 4},
+		{"functions", 1},
+		{"crypto", 0},
+	}
+	for _, test := range tests {
+		got := rankTerm(pkg, test.term)
+		if got != test.want {
+			t.Errorf("rankTerm(%q): expected %d, got %d", test.term, test.want, got)
+		}
+	}
+}
+
+func TestManager_Provides(t *testing.T) {
+	ctx := context.Background()
+	m := setupTestRepo(t)
+	defer teardownTestRepo(t)
+	copyTestRepo(t, "")
+	m.Client = httpx.Mock("index")
+
+	cfg := &config.Config{Registries: []spec.Registry{
+		{Name: "internal", URL: "https://example.org/{owner}/{name}.json", Index: "https://example.org/index.json"},
+	}}
+	err := m.SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig: unexpected error %v", err)
+	}
+
+	t.Run("installed", func(t *testing.T) {
+		results, err := m.Provides(ctx, "EXAMPLE_FN")
+		if err != nil {
+			t.Fatalf("Provides: unexpected error %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Provides: expected 1 result, got %d", len(results))
+		}
+		if results[0].Package.FullName() != "nalgeon/example" || !results[0].Installed {
+			t.Errorf("Provides: unexpected result %+v", results[0])
+		}
+	})
+	t.Run("index", func(t *testing.T) {
+		results, err := m.Provides(ctx, "median")
+		if err != nil {
+			t.Fatalf("Provides: unexpected error %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Provides: expected 1 result, got %d", len(results))
+		}
+		if results[0].Package.FullName() != "nalgeon/stats" || results[0].Installed {
+			t.Errorf("Provides: unexpected result %+v", results[0])
+		}
+	})
+	t.Run("no index", func(t *testing.T) {
+		mem := logx.Mock(m.Logger)
+		m.Client = httpx.Mock("missing")
+		err := os.RemoveAll(m.IndexCacheDir())
+		if err != nil {
+			t.Fatalf("RemoveAll: unexpected error %v", err)
+		}
+		results, err := m.Provides(ctx, "example_version")
+		if err != nil {
+			t.Fatalf("Provides: unexpected error %v", err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("Provides: expected 1 result, got %d", len(results))
+		}
+		mem.MustHave(t, "failed to read registry index, using installed packages only")
+	})
+}
@@ -0,0 +1,113 @@
+// Functions that verify package signatures.
+package sqlpkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sqlpkg.org/cli/checksums"
+	"sqlpkg.org/cli/minisign"
+	"sqlpkg.org/cli/spec"
+)
+
+// verifyChecksums verifies the signature of the checksum file data.
+// The signature file (e.g. checksums.txt.minisig) is expected
+// next to the checksum file. Uses keys from the trust store if there are any
+// for the package owner. Otherwise, uses the key from the package spec,
+// which is pinned in the lockfile on first install (trust on first use).
+func (m *Manager) verifyChecksums(ctx context.Context, pkg *spec.Package, path *spec.AssetPath, data []byte) error {
+	keys, err := m.signingKeys(pkg)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		m.Logger.Debug("package is not signed")
+		return nil
+	}
+
+	sigPath := &spec.AssetPath{Value: path.Value + minisign.FileExt, IsRemote: path.IsRemote}
+	if !checksums.Exists(ctx, m.Client, sigPath.Value, sigPath.IsRemote) {
+		return fmt.Errorf("missing checksum signature: %s", sigPath)
+	}
+	sigData, err := checksums.Load(ctx, m.Client, sigPath.Value, sigPath.IsRemote)
+	if err != nil {
+		return fmt.Errorf("failed to read checksum signature: %w", err)
+	}
+
+	key, err := minisign.Verify(keys, data, sigData)
+	if err != nil {
+		return fmt.Errorf("invalid checksum signature: %w", err)
+	}
+
+	m.Logger.Debug("checksum signature is valid, key id = %s", key.ID())
+	return nil
+}
+
+// signingKeys returns public keys that can sign the package checksums.
+func (m *Manager) signingKeys(pkg *spec.Package) ([]*minisign.PublicKey, error) {
+	store, err := m.ReadTrustStore()
+	if err != nil {
+		return nil, err
+	}
+	if trusted := store.Get(pkg.Owner); len(trusted) != 0 {
+		m.Logger.Debug("using %d trusted keys for %s", len(trusted), pkg.Owner)
+		return parseKeys(trusted...)
+	}
+
+	pinned, err := m.pinnedKey(pkg)
+	if err != nil {
+		return nil, err
+	}
+	if pinned == "" && pkg.Publickey == "" {
+		return nil, nil
+	}
+	if pinned == "" {
+		m.Logger.Debug("trusting package key on first use")
+		return parseKeys(pkg.Publickey)
+	}
+
+	if pkg.Publickey != "" && !sameKey(pkg.Publickey, pinned) {
+		return nil, fmt.Errorf(
+			"package key has changed since it was pinned in the lockfile; "+
+				"run `sqlpkg trust add %s <key>` if you trust the new key", pkg.Owner)
+	}
+	m.Logger.Debug("using package key pinned in the lockfile")
+	return parseKeys(pinned)
+}
+
+// pinnedKey returns the package key pinned in the lockfile (if any).
+func (m *Manager) pinnedKey(pkg *spec.Package) (string, error) {
+	lck, err := m.ReadLockfile()
+	if err != nil {
+		return "", err
+	}
+	lckPkg, ok := lck.Packages[pkg.FullName()]
+	if !ok {
+		return "", nil
+	}
+	return lckPkg.Publickey, nil
+}
+
+// parseKeys parses base64-encoded public keys.
+func parseKeys(strs ...string) ([]*minisign.PublicKey, error) {
+	keys := make([]*minisign.PublicKey, 0, len(strs))
+	for _, str := range strs {
+		key, err := minisign.ParsePublicKey(str)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, str)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sameKey checks if two strings represent the same public key.
+func sameKey(s1, s2 string) bool {
+	key1, err1 := minisign.ParsePublicKey(s1)
+	key2, err2 := minisign.ParsePublicKey(s2)
+	if err := errors.Join(err1, err2); err != nil {
+		return s1 == s2
+	}
+	return key1.String() == key2.String()
+}
@@ -0,0 +1,140 @@
+package sqlpkg
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"sqlpkg.org/cli/lockfile"
+	"sqlpkg.org/cli/spec"
+)
+
+const testPublicKey = "RWQBAgMEBQYHCNbfPh0yyFgOzPEFJwh6VAoknPwKGurCvJmBBXJrKg7g"
+const otherPublicKey = "RWQIBwYFBAMCAdbfPh0yyFgOzPEFJwh6VAoknPwKGurCvJmBBXJrKg7g"
+
+func TestVerifyChecksums(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
+	path := &spec.AssetPath{Value: "./testdata/signed/checksums.txt"}
+	data, err := os.ReadFile(path.Value)
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Publickey: testPublicKey}
+		err := m.verifyChecksums(ctx, pkg, path, data)
+		if err != nil {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+	t.Run("tampered", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Publickey: testPublicKey}
+		tampered := []byte(strings.Replace(string(data), "6bc2", "6bc3", 1))
+		err := m.verifyChecksums(ctx, pkg, path, tampered)
+		if err == nil || !strings.Contains(err.Error(), "invalid checksum signature") {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+	t.Run("not signed", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example"}
+		path := &spec.AssetPath{Value: "./testdata/checksums/checksums.txt"}
+		err := m.verifyChecksums(ctx, pkg, path, data)
+		if err != nil {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+	t.Run("missing signature", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Publickey: testPublicKey}
+		path := &spec.AssetPath{Value: "./testdata/checksums/checksums.txt"}
+		err := m.verifyChecksums(ctx, pkg, path, data)
+		if err == nil || !strings.Contains(err.Error(), "missing checksum signature") {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+	t.Run("pinned key changed", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		lck := lockfile.NewLockfile()
+		lck.Add(&spec.Package{Owner: "nalgeon", Name: "example", Publickey: otherPublicKey})
+		err := lck.Save(m.Dir)
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Publickey: testPublicKey}
+		err = m.verifyChecksums(ctx, pkg, path, data)
+		if err == nil || !strings.Contains(err.Error(), "package key has changed") {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+	t.Run("pinned key removed", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		lck := lockfile.NewLockfile()
+		lck.Add(&spec.Package{Owner: "nalgeon", Name: "example", Publickey: testPublicKey})
+		err := lck.Save(m.Dir)
+		if err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example"}
+		err = m.verifyChecksums(ctx, pkg, path, data)
+		if err != nil {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+		if pkg.Publickey != "" {
+			t.Errorf("VerifyChecksums: unexpected key %v", pkg.Publickey)
+		}
+
+		err = m.addToLockfile(lck, pkg)
+		if err != nil {
+			t.Fatalf("addToLockfile: unexpected error %v", err)
+		}
+		if key := lck.Packages[pkg.FullName()].Publickey; key != testPublicKey {
+			t.Errorf("addToLockfile: unexpected key %v", key)
+		}
+	})
+	t.Run("trusted key", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		store, _ := m.ReadTrustStore()
+		store.Add("nalgeon", testPublicKey)
+		err := m.SaveTrustStore(store)
+		if err != nil {
+			t.Fatalf("SaveTrustStore: %v", err)
+		}
+
+		// the trust store takes precedence over the spec key
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Publickey: otherPublicKey}
+		err = m.verifyChecksums(ctx, pkg, path, data)
+		if err != nil {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+	t.Run("untrusted key", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		store, _ := m.ReadTrustStore()
+		store.Add("nalgeon", otherPublicKey)
+		err := m.SaveTrustStore(store)
+		if err != nil {
+			t.Fatalf("SaveTrustStore: %v", err)
+		}
+
+		pkg := &spec.Package{Owner: "nalgeon", Name: "example", Publickey: testPublicKey}
+		err = m.verifyChecksums(ctx, pkg, path, data)
+		if err == nil || !strings.Contains(err.Error(), "invalid checksum signature") {
+			t.Fatalf("VerifyChecksums: unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,173 @@
+// Functions that manage package spec files.
+package sqlpkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"sqlpkg.org/cli/checksums"
+	"sqlpkg.org/cli/spec"
+)
+
+// ReadSpec reads package spec. Looks up owner-name pairs
+// in the configured registries.
+func (m *Manager) ReadSpec(ctx context.Context, path string) (*spec.Package, error) {
+	registries, err := m.registries()
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := spec.Read(ctx, m.Client, path, registries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package spec: %w", err)
+	}
+	err = m.inferAssetPath(pkg)
+	if err != nil {
+		return nil, err
+	}
+	pkg.ExpandVars()
+	m.Logger.Debug("found package spec at %s", pkg.Specfile)
+	if pkg.Registry != "" {
+		m.Logger.Debug("using registry %s", pkg.Registry)
+	}
+	m.Logger.Debug("read package %s, version = %s", pkg.FullName(), pkg.Version)
+	return pkg, nil
+}
+
+// inferAssetPath sets the default asset path of the repository provider
+// if the spec does not have one. ExpandVars only knows the public providers,
+// so this is needed for the configured ones.
+func (m *Manager) inferAssetPath(pkg *spec.Package) error {
+	if pkg.Assets.Path != nil && pkg.Assets.Path.Value != "" {
+		return nil
+	}
+	prov, err := m.provider(pkg.Repository)
+	if err != nil || prov == nil {
+		return err
+	}
+	pkg.Assets.Path = &spec.AssetPath{Value: prov.DownloadBase(), IsRemote: true}
+	return nil
+}
+
+// FindSpec loads the package spec, giving preference to already installed packages.
+func (m *Manager) FindSpec(ctx context.Context, path string) (*spec.Package, error) {
+	pkg := m.ReadInstalledSpec(path)
+	if pkg != nil {
+		return pkg, nil
+	}
+
+	m.Logger.Debug("package is not installed")
+	pkg, err := m.ReadSpec(ctx, path)
+	return pkg, err
+}
+
+// ReadInstalledSpec loads the package spec for an installed package (if any).
+func (m *Manager) ReadInstalledSpec(fullName string) *spec.Package {
+	path, err := m.PackagePath(fullName)
+	if err != nil {
+		return nil
+	}
+
+	pkg, err := spec.ReadLocal(path)
+	if err != nil {
+		return nil
+	}
+
+	m.Logger.Debug("found installed package")
+	return pkg
+}
+
+// List loads package specs for all installed packages,
+// sorted by full name.
+func (m *Manager) List() ([]*spec.Package, error) {
+	pattern := filepath.Join(m.RepoDir(), "*", "*", spec.FileName)
+	paths, _ := filepath.Glob(pattern)
+
+	packages := []*spec.Package{}
+	for _, path := range paths {
+		pkg, err := spec.ReadLocal(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid package spec: %s", path)
+		}
+		packages = append(packages, pkg)
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].FullName() < packages[j].FullName()
+	})
+
+	m.Logger.Debug("gathered %d packages", len(packages))
+	return packages, nil
+}
+
+// readChecksums reads package asset checksums from the checksum file
+// and verifies its signature if the package is signed.
+// Uses the checksum file from the package spec if it's set. Otherwise, tries
+// common checksum filenames, and then the sidecar checksum file
+// for the platform's asset (e.g. example-linux-x86.zip.sha256).
+func (m *Manager) readChecksums(ctx context.Context, pkg *spec.Package) error {
+	if pkg.Assets.ChecksumFile != "" {
+		path := pkg.Assets.Path.Join(pkg.Assets.ChecksumFile)
+		if !checksums.Exists(ctx, m.Client, path.Value, path.IsRemote) {
+			return fmt.Errorf("checksum file does not exist: %s", path)
+		}
+		return m.readChecksumFile(ctx, pkg, path, checksums.Parse)
+	}
+
+	for _, name := range checksums.FileNames {
+		path := pkg.Assets.Path.Join(name)
+		if checksums.Exists(ctx, m.Client, path.Value, path.IsRemote) {
+			return m.readChecksumFile(ctx, pkg, path, checksums.Parse)
+		}
+	}
+
+	if asset, ok := pkg.Assets.Files[m.Platform.String()]; ok {
+		for _, ext := range checksums.SidecarExts {
+			path := pkg.Assets.Path.Join(asset + ext)
+			if checksums.Exists(ctx, m.Client, path.Value, path.IsRemote) {
+				parse := func(data []byte) (map[string]string, error) {
+					return checksums.ParseSidecar(path.Value, data)
+				}
+				return m.readChecksumFile(ctx, pkg, path, parse)
+			}
+		}
+	}
+
+	keys, err := m.signingKeys(pkg)
+	if err != nil {
+		return err
+	}
+	if len(keys) != 0 {
+		return errors.New("missing checksum file for a signed package")
+	}
+
+	m.Logger.Debug("missing spec checksum file")
+	return nil
+}
+
+// readChecksumFile reads package asset checksums from the file,
+// verifying the file signature before parsing it.
+func (m *Manager) readChecksumFile(ctx context.Context, pkg *spec.Package, path *spec.AssetPath,
+	parse func(data []byte) (map[string]string, error)) error {
+	m.Logger.Debug("found checksum file %s", path)
+	data, err := checksums.Load(ctx, m.Client, path.Value, path.IsRemote)
+	if err != nil {
+		return fmt.Errorf("failed to read checksum file: %w", err)
+	}
+
+	err = m.verifyChecksums(ctx, pkg, path, data)
+	if err != nil {
+		return err
+	}
+
+	sums, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to read checksum file: %w", err)
+	}
+	m.Logger.Debug("read %d checksums", len(sums))
+	pkg.Assets.Checksums = sums
+	return nil
+}
@@ -0,0 +1,267 @@
+package sqlpkg
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	"sqlpkg.org/cli/config"
+	"sqlpkg.org/cli/provider"
+	"sqlpkg.org/cli/spec"
+)
+
+func TestReadSpec(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
+	t.Run("existing", func(t *testing.T) {
+		pkg, err := m.ReadSpec(ctx, "./testdata/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: unexpected error %v", err)
+		}
+		if pkg.FullName() != "nalgeon/example" || pkg.Version != "0.2.0" {
+			t.Errorf("ReadSpec: unexpected package %s@%s", pkg.FullName(), pkg.Version)
+		}
+		if pkg.Assets.Files["darwin-arm64"] != "example-0.2.0-darwin.zip" {
+			t.Errorf("ReadSpec: unexpected darwin asset %v", pkg.Assets.Files["darwin-arm64"])
+		}
+		if pkg.Assets.Files["linux-amd64"] != "example-0.2.0-linux.zip" {
+			t.Errorf("ReadSpec: unexpected linux asset %v", pkg.Assets.Files["linux-amd64"])
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, err := m.ReadSpec(ctx, "./testdata/missing.json")
+		if err == nil {
+			t.Fatal("ReadSpec: expected error, got nil")
+		}
+	})
+}
+
+func TestManager_inferAssetPath(t *testing.T) {
+	m := testManager()
+	m.Dir = t.TempDir()
+	cfg := &config.Config{Providers: []provider.Config{
+		{Type: provider.TypeGitea, APIURL: "https://git.example.org/api/v1"},
+	}}
+	err := m.SaveConfig(cfg)
+	if err != nil {
+		t.Fatalf("SaveConfig: unexpected error %v", err)
+	}
+
+	t.Run("configured", func(t *testing.T) {
+		pkg := &spec.Package{Repository: "https://git.example.org/nalgeon/example"}
+		err := m.inferAssetPath(pkg)
+		if err != nil {
+			t.Fatalf("inferAssetPath: unexpected error %v", err)
+		}
+		want := "{repository}/releases/download/{version}"
+		if pkg.Assets.Path == nil || pkg.Assets.Path.Value != want || !pkg.Assets.Path.IsRemote {
+			t.Errorf("inferAssetPath: unexpected Assets.Path %+v", pkg.Assets.Path)
+		}
+	})
+	t.Run("explicit", func(t *testing.T) {
+		path := &spec.AssetPath{Value: "https://example.org/downloads", IsRemote: true}
+		pkg := &spec.Package{
+			Repository: "https://git.example.org/nalgeon/example",
+			Assets:     spec.Assets{Path: path},
+		}
+		err := m.inferAssetPath(pkg)
+		if err != nil {
+			t.Fatalf("inferAssetPath: unexpected error %v", err)
+		}
+		if pkg.Assets.Path != path {
+			t.Errorf("inferAssetPath: unexpected Assets.Path %+v", pkg.Assets.Path)
+		}
+	})
+	t.Run("unknown", func(t *testing.T) {
+		pkg := &spec.Package{Repository: "https://example.org/nalgeon/example"}
+		err := m.inferAssetPath(pkg)
+		if err != nil {
+			t.Fatalf("inferAssetPath: unexpected error %v", err)
+		}
+		if pkg.Assets.Path != nil {
+			t.Errorf("inferAssetPath: unexpected Assets.Path %+v", pkg.Assets.Path)
+		}
+	})
+}
+
+func TestFindSpec(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
+	t.Run("installed", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
+
+		pkg, err := m.FindSpec(ctx, "nalgeon/example")
+		if err != nil {
+			t.Fatalf("FindSpec: unexpected error %v", err)
+		}
+		if pkg.FullName() != "nalgeon/example" || pkg.Version != "0.1.0" {
+			t.Errorf("FindSpec: unexpected package %s@%s", pkg.FullName(), pkg.Version)
+		}
+	})
+	t.Run("remote", func(t *testing.T) {
+
+		pkg, err := m.FindSpec(ctx, "nalgeon/example")
+		if err != nil {
+			t.Fatalf("FindSpec: unexpected error %v", err)
+		}
+		if pkg.FullName() != "nalgeon/example" || pkg.Version != "0.2.0" {
+			t.Errorf("FindSpec: unexpected package %s@%s", pkg.FullName(), pkg.Version)
+		}
+	})
+}
+
+func TestReadInstalledSpec(t *testing.T) {
+	m := testManager()
+	t.Run("existing", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
+
+		pkg := m.ReadInstalledSpec("nalgeon/example")
+		if pkg == nil {
+			t.Fatal("ReadInstalledSpec: expected package, got nil")
+		}
+		if pkg.FullName() != "nalgeon/example" || pkg.Version != "0.1.0" {
+			t.Errorf("ReadInstalledSpec: unexpected package %s@%s", pkg.FullName(), pkg.Version)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		pkg := m.ReadInstalledSpec("nalgeon/example")
+		if pkg != nil {
+			t.Fatalf("ReadInstalledSpec: expected nul, got %v", pkg)
+		}
+	})
+}
+
+func TestReadChecksums(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
+	t.Run("exist", func(t *testing.T) {
+		pkg, err := m.ReadSpec(ctx, "./testdata/checksums/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+
+		err = m.readChecksums(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ReadChecksums: unexpected error %v", err)
+		}
+		if len(pkg.Assets.Checksums) != 2 {
+			t.Fatalf("ReadChecksums: unexpected checksum count %v", len(pkg.Assets.Checksums))
+		}
+		if pkg.Assets.Checksums["example-darwin.zip"][:19] != "sha256-e3de533fdc23" {
+			t.Errorf(
+				"ReadChecksums: unexpected darwin checksum %v",
+				pkg.Assets.Checksums["example-darwin.zip"],
+			)
+		}
+		if pkg.Assets.Checksums["example-linux.zip"][:19] != "sha256-6bc24897dde2" {
+			t.Errorf(
+				"ReadChecksums: unexpected linux checksum %v",
+				pkg.Assets.Checksums["example-linux.zip"],
+			)
+		}
+	})
+	t.Run("checksum file", func(t *testing.T) {
+		pkg, err := m.ReadSpec(ctx, "./testdata/sumfile/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+
+		err = m.readChecksums(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ReadChecksums: unexpected error %v", err)
+		}
+		if len(pkg.Assets.Checksums) != 2 {
+			t.Fatalf("ReadChecksums: unexpected checksum count %v", len(pkg.Assets.Checksums))
+		}
+		if pkg.Assets.Checksums["example-0.2.0-linux.zip"][:19] != "sha256-6bc24897dde2" {
+			t.Errorf(
+				"ReadChecksums: unexpected linux checksum %v",
+				pkg.Assets.Checksums["example-0.2.0-linux.zip"],
+			)
+		}
+	})
+	t.Run("missing checksum file", func(t *testing.T) {
+		pkg, err := m.ReadSpec(ctx, "./testdata/sumfile/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+		pkg.Assets.ChecksumFile = "missing.txt"
+
+		err = m.readChecksums(ctx, pkg)
+		if err == nil {
+			t.Fatal("ReadChecksums: expected error, got nil")
+		}
+	})
+	t.Run("sidecar", func(t *testing.T) {
+		pkg, err := m.ReadSpec(ctx, "./testdata/sidecar/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+
+		err = m.readChecksums(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ReadChecksums: unexpected error %v", err)
+		}
+		if len(pkg.Assets.Checksums) != 1 {
+			t.Fatalf("ReadChecksums: unexpected checksum count %v", len(pkg.Assets.Checksums))
+		}
+		asset := fmt.Sprintf("example-0.2.0-%s.zip", runtime.GOOS)
+		if _, ok := pkg.Assets.Checksums[asset]; !ok {
+			t.Errorf("ReadChecksums: missing %s checksum", asset)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		pkg, err := m.ReadSpec(ctx, "./testdata/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+
+		err = m.readChecksums(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ReadChecksums: unexpected error %v", err)
+		}
+		if len(pkg.Assets.Checksums) != 0 {
+			t.Fatalf("ReadChecksums: unexpected checksum count %v", len(pkg.Assets.Checksums))
+		}
+	})
+}
+
+func TestReadChecksums_Signed(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
+	t.Run("valid", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		pkg, err := m.ReadSpec(ctx, "./testdata/signed/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+
+		err = m.readChecksums(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ReadChecksums: unexpected error %v", err)
+		}
+		if len(pkg.Assets.Checksums) != 2 {
+			t.Fatalf("ReadChecksums: unexpected checksum count %v", len(pkg.Assets.Checksums))
+		}
+	})
+	t.Run("missing checksum file", func(t *testing.T) {
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		pkg, err := m.ReadSpec(ctx, "./testdata/sqlpkg.json")
+		if err != nil {
+			t.Fatalf("ReadSpec: %v", err)
+		}
+		pkg.Publickey = testPublicKey
+
+		err = m.readChecksums(ctx, pkg)
+		if err == nil {
+			t.Fatal("ReadChecksums: expected error, got nil")
+		}
+	})
+}
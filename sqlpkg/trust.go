@@ -0,0 +1,34 @@
+// Functions that manage the trust store.
+package sqlpkg
+
+import (
+	"fmt"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/trust"
+)
+
+// ReadTrustStore reads the trust store from the work directory.
+func (m *Manager) ReadTrustStore() (*trust.Store, error) {
+	path := trust.Path(m.Dir)
+	if !fileio.Exists(path) {
+		return trust.NewStore(), nil
+	}
+
+	store, err := trust.ReadLocal(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust store: %w", err)
+	}
+
+	m.Logger.Debug("read trust store")
+	return store, nil
+}
+
+// SaveTrustStore writes the trust store to the work directory.
+func (m *Manager) SaveTrustStore(store *trust.Store) error {
+	err := store.Save(m.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to save trust store: %w", err)
+	}
+	return nil
+}
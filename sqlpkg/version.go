@@ -0,0 +1,83 @@
+// Functions that manage the package version.
+package sqlpkg
+
+import (
+	"context"
+	"fmt"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/provider"
+	"sqlpkg.org/cli/semver"
+	"sqlpkg.org/cli/spec"
+)
+
+// resolveVersion resolves the latest version if needed.
+func (m *Manager) resolveVersion(ctx context.Context, pkg *spec.Package) error {
+	if pkg.Version != "latest" {
+		return nil
+	}
+
+	prov, err := m.provider(pkg.Repository)
+	if err != nil {
+		return err
+	}
+	if prov == nil {
+		hostname := httpx.Hostname(pkg.Repository)
+		m.Logger.Debug("unknown provider %s, not resolving version", hostname)
+		return nil
+	}
+
+	owner, repo, err := provider.ParseRepoUrl(pkg.Repository)
+	if err != nil {
+		return fmt.Errorf("failed to parse repo url: %v", err)
+	}
+
+	version, err := prov.LatestRelease(ctx, m.Client, owner, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get latest tag: %w", err)
+	}
+
+	pkg.ReplaceLatest(version)
+	m.Logger.Debug("resolved latest version = %s", version)
+	return nil
+}
+
+// provider returns the code hosting provider of the repository
+// (configured or known), or nil if it's unknown.
+func (m *Manager) provider(repoUrl string) (provider.Provider, error) {
+	cfg, err := m.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	prov, err := provider.ForRepo(repoUrl, cfg.Providers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return prov, nil
+}
+
+// hasNewVersion checks if the remote package is newer than the installed one.
+func (m *Manager) hasNewVersion(remotePkg *spec.Package) bool {
+	installPath := spec.Path(m.Dir, remotePkg.Owner, remotePkg.Name)
+	if !fileio.Exists(installPath) {
+		return true
+	}
+
+	installedPkg, err := spec.ReadLocal(installPath)
+	if err != nil {
+		return true
+	}
+	m.Logger.Debug("local package version = %s", installedPkg.Version)
+
+	if installedPkg.Version == "" {
+		// not explicitly versioned, always assume there is a later version
+		return true
+	}
+
+	if installedPkg.Version == remotePkg.Version {
+		return false
+	}
+
+	return semver.Compare(installedPkg.Version, remotePkg.Version) < 0
+}
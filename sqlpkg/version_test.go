@@ -1,13 +1,20 @@
-package cmd
+package sqlpkg
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"sqlpkg.org/cli/config"
 	"sqlpkg.org/cli/httpx"
+	"sqlpkg.org/cli/provider"
 	"sqlpkg.org/cli/spec"
 )
 
 func TestResolveVersion(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
 	t.Run("specific", func(t *testing.T) {
 		pkg := &spec.Package{
 			Owner: "nalgeon", Name: "example", Version: "0.1.0",
@@ -24,7 +31,7 @@ func TestResolveVersion(t *testing.T) {
 			},
 		}
 
-		err := ResolveVersion(pkg)
+		err := m.resolveVersion(ctx, pkg)
 		if err != nil {
 			t.Fatalf("ResolveVersion: unexpected error %v", err)
 		}
@@ -40,7 +47,7 @@ func TestResolveVersion(t *testing.T) {
 		}
 	})
 	t.Run("latest", func(t *testing.T) {
-		httpx.Mock("github")
+		m.Client = httpx.Mock("github")
 		pkg := &spec.Package{
 			Owner: "nalgeon", Name: "example", Version: "latest",
 			Repository: "https://github.com/nalgeon/example",
@@ -56,7 +63,7 @@ func TestResolveVersion(t *testing.T) {
 			},
 		}
 
-		err := ResolveVersion(pkg)
+		err := m.resolveVersion(ctx, pkg)
 		if err != nil {
 			t.Fatalf("ResolveVersion: unexpected error %v", err)
 		}
@@ -71,62 +78,122 @@ func TestResolveVersion(t *testing.T) {
 			t.Errorf("ResolveVersion: unexpected Assets.Files %v", pkg.Assets.Files)
 		}
 	})
+	t.Run("configured provider", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != "/api/v4/projects/nalgeon%2Fexample/releases" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(`[{"tag_name":"0.3.0"},{"tag_name":"0.2.0"}]`))
+		}))
+		defer srv.Close()
+
+		m := testManager()
+		m.Dir = t.TempDir()
+		m.Client = srv.Client()
+		cfg := &config.Config{Providers: []provider.Config{
+			{Type: provider.TypeGitLab, APIURL: srv.URL + "/api/v4", Host: "git.example.org"},
+		}}
+		err := m.SaveConfig(cfg)
+		if err != nil {
+			t.Fatalf("SaveConfig: unexpected error %v", err)
+		}
+
+		pkg := &spec.Package{
+			Owner: "nalgeon", Name: "example", Version: "latest",
+			Repository: "https://git.example.org/nalgeon/example",
+			Assets: spec.Assets{
+				Path: &spec.AssetPath{
+					Value:    "https://git.example.org/nalgeon/example/-/releases/{latest}/downloads",
+					IsRemote: true,
+				},
+				Files: map[string]string{"linux-amd64": "example-{latest}-linux.zip"},
+			},
+		}
+		err = m.resolveVersion(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ResolveVersion: unexpected error %v", err)
+		}
+		if pkg.Version != "0.3.0" {
+			t.Errorf("ResolveVersion: unexpected Version %v", pkg.Version)
+		}
+		if pkg.Assets.Path.Value != "https://git.example.org/nalgeon/example/-/releases/0.3.0/downloads" {
+			t.Errorf("ResolveVersion: unexpected Assets.Path %v", pkg.Assets.Path.Value)
+		}
+	})
+	t.Run("unknown provider", func(t *testing.T) {
+		m := testManager()
+		m.Dir = t.TempDir()
+		pkg := &spec.Package{
+			Owner: "nalgeon", Name: "example", Version: "latest",
+			Repository: "https://git.example.org/nalgeon/example",
+		}
+		err := m.resolveVersion(ctx, pkg)
+		if err != nil {
+			t.Fatalf("ResolveVersion: unexpected error %v", err)
+		}
+		if pkg.Version != "latest" {
+			t.Errorf("ResolveVersion: unexpected Version %v", pkg.Version)
+		}
+	})
 }
 
 func TestHasNewVersion(t *testing.T) {
+	ctx := context.Background()
+	m := testManager()
 	t.Run("yes", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
-		CopyTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
 
-		pkg, err := ReadSpec("./testdata/sqlpkg.json")
+		pkg, err := m.ReadSpec(ctx, "./testdata/sqlpkg.json")
 		if err != nil {
 			t.Fatalf("ReadSpec: %v", err)
 		}
 
-		has := HasNewVersion(pkg)
+		has := m.hasNewVersion(pkg)
 		if !has {
 			t.Errorf("HasNewVersion: expected true, got false")
 		}
 	})
 	t.Run("no", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
-		CopyTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
 
-		pkg, err := ReadSpec("./testdata/sqlpkg.json")
+		pkg, err := m.ReadSpec(ctx, "./testdata/sqlpkg.json")
 		if err != nil {
 			t.Fatalf("ReadSpec: %v", err)
 		}
 		pkg.Version = "0.1.0"
 
-		has := HasNewVersion(pkg)
+		has := m.hasNewVersion(pkg)
 		if has {
 			t.Errorf("HasNewVersion: expected false, got true")
 		}
 	})
 	t.Run("not versioned", func(t *testing.T) {
-		SetupTestRepo(t)
-		defer TeardownTestRepo(t)
-		CopyTestRepo(t)
+		setupTestRepo(t)
+		defer teardownTestRepo(t)
+		copyTestRepo(t)
 
-		pkg, err := ReadSpec("./testdata/.sqlpkg/sqlite/stmt/sqlpkg.json")
+		pkg, err := m.ReadSpec(ctx, "./testdata/.sqlpkg/sqlite/stmt/sqlpkg.json")
 		if err != nil {
 			t.Fatalf("ReadSpec: %v", err)
 		}
 
-		has := HasNewVersion(pkg)
+		has := m.hasNewVersion(pkg)
 		if !has {
 			t.Errorf("HasNewVersion: expected true, got false")
 		}
 	})
 	t.Run("not installed", func(t *testing.T) {
-		pkg, err := ReadSpec("./testdata/sqlpkg.json")
+		pkg, err := m.ReadSpec(ctx, "./testdata/sqlpkg.json")
 		if err != nil {
 			t.Fatalf("ReadSpec: %v", err)
 		}
 
-		has := HasNewVersion(pkg)
+		has := m.hasNewVersion(pkg)
 		if !has {
 			t.Errorf("HasNewVersion: expected true, got false")
 		}
@@ -0,0 +1,108 @@
+// Package trust manages the local trust store (trust.json)
+// with package signing keys explicitly trusted by the user.
+package trust
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"sqlpkg.org/cli/fileio"
+	"sqlpkg.org/cli/spec"
+)
+
+// FileName is the trust store filename.
+const FileName = "trust.json"
+
+// Path returns the path to the trust store.
+func Path(basePath string) string {
+	return filepath.Join(basePath, spec.DirName, FileName)
+}
+
+// A Store maps package owners to their trusted public keys.
+type Store struct {
+	Keys map[string][]string `json:"keys"`
+}
+
+// NewStore creates an empty trust store.
+func NewStore() *Store {
+	return &Store{Keys: map[string][]string{}}
+}
+
+// Get returns trusted keys for the owner.
+func (s *Store) Get(owner string) []string {
+	return s.Keys[owner]
+}
+
+// Add adds a trusted key for the owner.
+// Returns false if the key is already trusted.
+func (s *Store) Add(owner, key string) bool {
+	if slices.Contains(s.Keys[owner], key) {
+		return false
+	}
+	s.Keys[owner] = append(s.Keys[owner], key)
+	return true
+}
+
+// Remove removes a trusted key for the owner.
+// If the key is empty, removes all keys for the owner.
+// Returns false if there was nothing to remove.
+func (s *Store) Remove(owner, key string) bool {
+	keys, ok := s.Keys[owner]
+	if !ok {
+		return false
+	}
+	if key == "" {
+		delete(s.Keys, owner)
+		return true
+	}
+	idx := slices.Index(keys, key)
+	if idx == -1 {
+		return false
+	}
+	keys = slices.Delete(keys, idx, idx+1)
+	if len(keys) == 0 {
+		delete(s.Keys, owner)
+	} else {
+		s.Keys[owner] = keys
+	}
+	return true
+}
+
+// Owners returns owners with trusted keys, sorted alphabetically.
+func (s *Store) Owners() []string {
+	owners := make([]string, 0, len(s.Keys))
+	for owner := range s.Keys {
+		owners = append(owners, owner)
+	}
+	sort.Strings(owners)
+	return owners
+}
+
+// Save writes the trust store to the specified base directory.
+func (s *Store) Save(basePath string) error {
+	data, err := json.MarshalIndent(s, "", "    ")
+	if err != nil {
+		return err
+	}
+	path := Path(basePath)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadLocal reads the trust store from a local file.
+func ReadLocal(path string) (*Store, error) {
+	store, err := fileio.ReadJSON[Store](path)
+	if err != nil {
+		return nil, err
+	}
+	if store.Keys == nil {
+		store.Keys = map[string][]string{}
+	}
+	return store, nil
+}
@@ -0,0 +1,119 @@
+package trust
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	got := Path("testdata")
+	if got != filepath.Join("testdata", ".sqlpkg", FileName) {
+		t.Errorf("Path: unexpected value %v", got)
+	}
+}
+
+func TestStore_Add(t *testing.T) {
+	store := NewStore()
+	t.Run("add", func(t *testing.T) {
+		ok := store.Add("nalgeon", "key1")
+		if !ok {
+			t.Error("Add: expected true, got false")
+		}
+		ok = store.Add("nalgeon", "key2")
+		if !ok {
+			t.Error("Add: expected true, got false")
+		}
+		if !reflect.DeepEqual(store.Get("nalgeon"), []string{"key1", "key2"}) {
+			t.Errorf("Add: unexpected keys %v", store.Get("nalgeon"))
+		}
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		ok := store.Add("nalgeon", "key1")
+		if ok {
+			t.Error("Add: expected false, got true")
+		}
+		if len(store.Get("nalgeon")) != 2 {
+			t.Errorf("Add: unexpected keys %v", store.Get("nalgeon"))
+		}
+	})
+}
+
+func TestStore_Remove(t *testing.T) {
+	t.Run("key", func(t *testing.T) {
+		store := NewStore()
+		store.Add("nalgeon", "key1")
+		store.Add("nalgeon", "key2")
+		ok := store.Remove("nalgeon", "key1")
+		if !ok {
+			t.Error("Remove: expected true, got false")
+		}
+		if !reflect.DeepEqual(store.Get("nalgeon"), []string{"key2"}) {
+			t.Errorf("Remove: unexpected keys %v", store.Get("nalgeon"))
+		}
+	})
+	t.Run("last key", func(t *testing.T) {
+		store := NewStore()
+		store.Add("nalgeon", "key1")
+		store.Remove("nalgeon", "key1")
+		if len(store.Owners()) != 0 {
+			t.Errorf("Remove: unexpected owners %v", store.Owners())
+		}
+	})
+	t.Run("owner", func(t *testing.T) {
+		store := NewStore()
+		store.Add("nalgeon", "key1")
+		store.Add("nalgeon", "key2")
+		ok := store.Remove("nalgeon", "")
+		if !ok {
+			t.Error("Remove: expected true, got false")
+		}
+		if len(store.Get("nalgeon")) != 0 {
+			t.Errorf("Remove: unexpected keys %v", store.Get("nalgeon"))
+		}
+	})
+	t.Run("missing", func(t *testing.T) {
+		store := NewStore()
+		store.Add("nalgeon", "key1")
+		if store.Remove("nalgeon", "key2") {
+			t.Error("Remove: expected false, got true")
+		}
+		if store.Remove("sqlite", "") {
+			t.Error("Remove: expected false, got true")
+		}
+	})
+}
+
+func TestStore_Owners(t *testing.T) {
+	store := NewStore()
+	store.Add("sqlite", "key1")
+	store.Add("nalgeon", "key2")
+	if !reflect.DeepEqual(store.Owners(), []string{"nalgeon", "sqlite"}) {
+		t.Errorf("Owners: unexpected value %v", store.Owners())
+	}
+}
+
+func TestSave(t *testing.T) {
+	store := NewStore()
+	store.Add("nalgeon", "key1")
+	dir := t.TempDir()
+	err := store.Save(dir)
+	if err != nil {
+		t.Fatalf("Save: unexpected error %v", err)
+	}
+
+	got, err := ReadLocal(Path(dir))
+	if err != nil {
+		t.Fatalf("ReadLocal: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got.Get("nalgeon"), []string{"key1"}) {
+		t.Errorf("Save: unexpected keys %v", got.Get("nalgeon"))
+	}
+}
+
+func TestReadLocal(t *testing.T) {
+	_, err := ReadLocal(filepath.Join("testdata", "missing.json"))
+	if err == nil {
+		t.Fatal("ReadLocal: expected error, got nil")
+	}
+}